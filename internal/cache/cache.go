@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	AppDirName = "jiractl"
+)
+
+// Dir returns the cache directory for the given Jira server, creating it if needed
+func Dir(server string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+
+	dir := filepath.Join(base, AppDirName, serverDirName(server))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return dir, nil
+}
+
+// Load reads a cached entry into v. It returns false if the entry does not exist
+// or is older than ttl. A ttl of zero or less means the entry never expires.
+func Load(server, name string, ttl time.Duration, v interface{}) (bool, error) {
+	dir, err := Dir(server)
+	if err != nil {
+		return false, err
+	}
+
+	return loadEntry(dir, name, ttl, v)
+}
+
+// loadEntry reads a JSON entry from dir into v, treating expired or corrupt entries as missing
+func loadEntry(dir, name string, ttl time.Duration, v interface{}) (bool, error) {
+	path := filepath.Join(dir, fileName(name))
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat entry: %w", err)
+	}
+	if ttl > 0 && time.Since(info.ModTime()) > ttl {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read entry: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		// A corrupt entry is treated as a miss so it gets refreshed
+		return false, nil
+	}
+	return true, nil
+}
+
+// Save writes v to the cache under the given name
+func Save(server, name string, v interface{}) error {
+	dir, err := Dir(server)
+	if err != nil {
+		return err
+	}
+	return writeEntry(dir, name, v)
+}
+
+// writeEntry encodes v as JSON into dir. It writes to a temp file first so
+// concurrent readers never see partial data.
+func writeEntry(dir, name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, fileName(name))); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write entry: %w", err)
+	}
+	return nil
+}
+
+// serverDirName turns a server URL into a directory name, e.g.
+// "https://acme.atlassian.net" becomes "acme.atlassian.net"
+func serverDirName(server string) string {
+	name := server
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		name = u.Host + u.Path
+	}
+	return sanitize(name)
+}
+
+func fileName(name string) string {
+	return sanitize(name) + ".json"
+}
+
+func sanitize(s string) string {
+	s = strings.Trim(s, "/")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '.' || r == '-' || r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// Remove deletes the named entries for the given server. Missing entries are ignored.
+func Remove(server string, names ...string) error {
+	dir, err := Dir(server)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(dir, fileName(name))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// RemovePrefix deletes every entry for the given server whose name starts with prefix
+func RemovePrefix(server, prefix string) error {
+	dir, err := Dir(server)
+	if err != nil {
+		return err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, sanitize(prefix)+"*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list entries: %w", err)
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// Clear removes all cached entries for the given server
+func Clear(server string) error {
+	dir, err := Dir(server)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
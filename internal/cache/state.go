@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// StateDir returns the state directory for the given Jira server, creating it if needed.
+// State (such as query snapshots) lives outside the cache so clearing the cache keeps it.
+// It is $XDG_STATE_HOME/jiractl, defaulting to ~/.local/state/jiractl.
+func StateDir(server string) (string, error) {
+	base := os.Getenv("XDG_STATE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		base = filepath.Join(home, ".local", "state")
+	}
+
+	dir := filepath.Join(base, AppDirName, serverDirName(server))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return dir, nil
+}
+
+// LoadState reads a state entry into v. It returns false if the entry does not exist.
+func LoadState(server, name string, v interface{}) (bool, error) {
+	dir, err := StateDir(server)
+	if err != nil {
+		return false, err
+	}
+	return loadEntry(dir, name, 0, v)
+}
+
+// SaveState writes v to the state directory under the given name
+func SaveState(server, name string, v interface{}) error {
+	dir, err := StateDir(server)
+	if err != nil {
+		return err
+	}
+	return writeEntry(dir, name, v)
+}
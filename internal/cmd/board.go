@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+	"github.com/spf13/cobra"
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Work with agile boards",
+}
+
+var boardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List boards for the configured project",
+	Args:  cobra.NoArgs,
+	RunE:  runBoardList,
+}
+
+var boardShowCmd = &cobra.Command{
+	Use:   "show [board]",
+	Short: "Show a board's filter, columns and active sprints",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runBoardShow,
+}
+
+var (
+	boardListAll     bool
+	boardListProject string
+)
+
+func init() {
+	RootCmd.AddCommand(boardCmd)
+	boardCmd.AddCommand(boardListCmd)
+	boardCmd.AddCommand(boardShowCmd)
+
+	boardListCmd.Flags().BoolVar(&boardListAll, "all", false, "List boards in all projects")
+	boardListCmd.Flags().StringVar(&boardListProject, "project", "", "Project key (defaults to the configured project)")
+}
+
+func runBoardList(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	project := boardListProject
+	if project == "" && !boardListAll {
+		project = cfg.Project
+	}
+
+	boards, err := client.GetBoards(project)
+	if err != nil {
+		return err
+	}
+	if len(boards) == 0 {
+		fmt.Println("No boards found.")
+		return nil
+	}
+
+	width := 0
+	for _, b := range boards {
+		width = max(width, len(b.Name))
+	}
+	for _, b := range boards {
+		fmt.Printf("%-6d %-7s %-*s  %s\n", b.ID, b.Type, width, b.Name, b.Location.ProjectKey)
+	}
+	return nil
+}
+
+func runBoardShow(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	arg := ""
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	board, err := resolveBoard(cfg, client, arg)
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	boardConfig, err := client.GetBoardConfiguration(board.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (%d)\n", board.Name, board.ID)
+	fmt.Printf("─────────────────────────────────────────────────────────\n")
+	fmt.Printf("Type:        %s\n", board.Type)
+	if board.Location.ProjectKey != "" {
+		fmt.Printf("Project:     %s\n", board.Location.ProjectKey)
+	}
+	if filter, err := client.GetFilter(boardConfig.Filter.ID); err == nil {
+		fmt.Printf("Filter:      %s (%s)\n", filter.Name, filter.ID)
+		fmt.Printf("JQL:         %s\n", filter.Jql)
+	} else {
+		fmt.Printf("Filter:      %s\n", boardConfig.Filter.ID)
+	}
+	if boardConfig.SubQuery.Query != "" {
+		fmt.Printf("Sub-filter:  %s\n", boardConfig.SubQuery.Query)
+	}
+
+	fmt.Println("\nColumns:")
+	for _, col := range boardConfig.ColumnConfig.Columns {
+		fmt.Printf("  %s\n", col.Name)
+	}
+
+	if board.Type == "scrum" {
+		sprints, err := client.GetSprints(board.ID, jira.SprintActive)
+		if err != nil {
+			return err
+		}
+		if len(sprints) > 0 {
+			fmt.Println("\nActive sprints:")
+			for _, s := range sprints {
+				fmt.Printf("  %s\n", formatSprintLine(s))
+			}
+		}
+	}
+	return nil
+}
+
+// resolveBoard finds a board by ID or name. Without one it uses the configured board,
+// the project's only board, or asks the user to pick one of the project's boards.
+func resolveBoard(cfg *config.Config, client *jira.Client, arg string) (*jira.Board, error) {
+	if arg == "" {
+		arg = cfg.Board
+	}
+	if id, err := strconv.Atoi(arg); err == nil {
+		return client.GetBoard(id)
+	}
+
+	boards, err := client.GetBoards(cfg.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	if arg == "" {
+		switch len(boards) {
+		case 0:
+			return nil, fmt.Errorf("no boards found for project %s", cfg.Project)
+		case 1:
+			return &boards[0], nil
+		}
+
+		items := make([]string, len(boards))
+		for i, b := range boards {
+			items[i] = fmt.Sprintf("%s (%s)", b.Name, b.Type)
+		}
+		idx, err := fzfSelect(items, "Select board")
+		if err != nil {
+			return nil, err
+		}
+		return &boards[idx], nil
+	}
+
+	var matches []jira.Board
+	for _, b := range boards {
+		if strings.EqualFold(b.Name, arg) {
+			matches = append(matches, b)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("board not found: %s", arg)
+	case 1:
+		return &matches[0], nil
+	}
+	return nil, fmt.Errorf("board name %q is ambiguous; use its ID", arg)
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/kanban"
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+	"github.com/spf13/cobra"
+)
+
+var boardViewCmd = &cobra.Command{
+	Use:   "view [board]",
+	Short: "Show a board as a full-screen Kanban board",
+	Long: `Show a board's columns and cards in the terminal.
+
+Scrum boards show the active sprint; Kanban boards show every issue on the board.
+
+Keys:
+  ←↓↑→ or hjkl        select a card
+  shift+←→ or H L     move the card to the next column (runs the matching transition)
+  enter               show the card's details
+  1-9, 0              toggle a quick filter, clear all quick filters
+  r                   refresh now
+  q or esc            quit`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBoardView,
+}
+
+var boardViewInterval time.Duration
+
+// boardViewFields are the fields shown on Kanban cards
+var boardViewFields = []string{"summary", "status", "assignee", "issuetype"}
+
+func init() {
+	boardCmd.AddCommand(boardViewCmd)
+	boardViewCmd.Flags().DurationVar(&boardViewInterval, "interval", 60*time.Second, "Time between refreshes (0 disables them)")
+}
+
+func runBoardView(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if boardViewInterval > 0 && boardViewInterval < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	arg := ""
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	board, err := resolveBoard(cfg, client, arg)
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	boardConfig, err := client.GetBoardConfiguration(board.ID)
+	if err != nil {
+		return err
+	}
+	quickFilters, err := client.GetQuickFilters(board.ID)
+	if err != nil {
+		return err
+	}
+
+	source := &boardSource{
+		client:   client,
+		board:    board,
+		subQuery: boardConfig.SubQuery.Query,
+		filters:  make(map[int]string, len(quickFilters)),
+		statuses: make(map[string]int),
+	}
+	for i, col := range boardConfig.ColumnConfig.Columns {
+		source.columns = append(source.columns, col.Name)
+		for _, status := range col.Status {
+			source.statuses[status.ID] = i
+		}
+	}
+
+	view := &kanban.View{
+		Title:   fmt.Sprintf("%s (%d)", board.Name, board.ID),
+		Source:  source,
+		Refresh: boardViewInterval,
+		URL:     func(key string) string { return issueURL(cfg.Server, key) },
+	}
+	for _, f := range quickFilters {
+		source.filters[f.ID] = f.JQL
+		view.Filters = append(view.Filters, kanban.Filter{ID: f.ID, Name: f.Name})
+	}
+
+	return view.Run()
+}
+
+// boardSource loads a board's issues into its columns and moves them with workflow transitions
+type boardSource struct {
+	client   *jira.Client
+	board    *jira.Board
+	subQuery string
+	columns  []string
+	filters  map[int]string // quick filter JQL by ID
+	statuses map[string]int // column index by status ID
+}
+
+// Load fetches the active sprint of a scrum board, or the issues of a Kanban board
+func (s *boardSource) Load(filters []int) ([]kanban.Column, error) {
+	var clauses []string
+	if s.board.Type != "scrum" && s.subQuery != "" {
+		clauses = append(clauses, "("+s.subQuery+")")
+	}
+	for _, id := range filters {
+		clauses = append(clauses, "("+s.filters[id]+")")
+	}
+	jql := strings.Join(clauses, " AND ")
+
+	var issues []jira.RawIssue
+	if s.board.Type == "scrum" {
+		sprints, err := s.client.GetSprints(s.board.ID, jira.SprintActive)
+		if err != nil {
+			return nil, err
+		}
+		if len(sprints) == 0 {
+			return nil, fmt.Errorf("no active sprint on board %s", s.board.Name)
+		}
+		for _, sprint := range sprints {
+			sprintIssues, err := s.client.GetSprintIssues(sprint.ID, jql, boardViewFields)
+			if err != nil {
+				return nil, err
+			}
+			issues = append(issues, sprintIssues...)
+		}
+	} else {
+		var err error
+		issues, err = s.client.GetBoardIssues(s.board.ID, jql, boardViewFields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	columns := make([]kanban.Column, len(s.columns))
+	for i, name := range s.columns {
+		columns[i].Name = name
+	}
+	for _, issue := range issues {
+		// Like Jira, leave out issues whose status is not mapped to a column
+		col, ok := s.statuses[issueStatusID(issue)]
+		if !ok {
+			continue
+		}
+		columns[col].Cards = append(columns[col].Cards, kanban.Card{
+			Key:      issue.Key,
+			Summary:  jira.FormatFieldValue(issue.Fields["summary"]),
+			Assignee: jira.FormatFieldValue(issue.Fields["assignee"]),
+			Type:     jira.FormatFieldValue(issue.Fields["issuetype"]),
+			Status:   jira.FormatFieldValue(issue.Fields["status"]),
+		})
+	}
+	return columns, nil
+}
+
+// Move runs the first available transition that leads to one of the column's statuses
+func (s *boardSource) Move(card kanban.Card, to int) error {
+	transitions, err := s.client.GetTransitions(card.Key)
+	if err != nil {
+		return err
+	}
+	for _, t := range transitions {
+		if col, ok := s.statuses[t.To.ID]; ok && col == to {
+			return s.client.TransitionIssue(card.Key, t.ID)
+		}
+	}
+	return fmt.Errorf("no transition moves %s from %s to %s", card.Key, card.Status, s.columns[to])
+}
+
+// issueStatusID returns the ID of an issue's status
+func issueStatusID(issue jira.RawIssue) string {
+	status, _ := issue.Fields["status"].(map[string]interface{})
+	id, _ := status["id"].(string)
+	return id
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/git"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var branchCmd = &cobra.Command{
+	Use:   "branch <key>",
+	Short: "Create and check out a git branch for an issue",
+	Long: `Create a git branch named after an issue and check it out. If the branch already
+exists it is checked out instead.
+
+The name comes from the branch_template in the [git] section of the config file, a Go
+template with the fields .Key, .Project, .Type and .Summary and the functions lower,
+upper and slug. The default is:
+
+  {{.Type | lower}}/{{.Key}}-{{.Summary | slug}}
+
+  jiractl branch PROJ-123                  # story/PROJ-123-add-login-page
+  jiractl branch PROJ-123 --start --assign # Also move it to In Progress and assign it to you`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBranch,
+}
+
+var (
+	branchStart  bool
+	branchAssign bool
+)
+
+func init() {
+	RootCmd.AddCommand(branchCmd)
+	branchCmd.Flags().BoolVar(&branchStart, "start", false, "Move the issue to the start status (default \"In Progress\")")
+	branchCmd.Flags().BoolVar(&branchAssign, "assign", false, "Assign the issue to yourself")
+}
+
+func runBranch(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	opts := branchOptionsFromFlags(cmd, cfg, branchStart, branchAssign)
+	return startBranch(cfg, client, strings.ToUpper(args[0]), opts)
+}
+
+// branchOptions selects what happens to the issue besides creating its branch
+type branchOptions struct {
+	start  bool
+	assign bool
+}
+
+// branchOptionsFromFlags returns the --start and --assign flags, defaulting to the config
+func branchOptionsFromFlags(cmd *cobra.Command, cfg *config.Config, start, assign bool) branchOptions {
+	opts := branchOptions{start: cfg.Git.Start, assign: cfg.Git.Assign}
+	if cmd.Flags().Changed("start") {
+		opts.start = start
+	}
+	if cmd.Flags().Changed("assign") {
+		opts.assign = assign
+	}
+	return opts
+}
+
+// startBranch creates (or checks out) the branch for an issue, then optionally starts
+// the issue and assigns it to the current user
+func startBranch(cfg *config.Config, client *jira.Client, key string, opts branchOptions) error {
+	if !git.InWorkTree() {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	issue, err := client.GetIssue(key)
+	if err != nil {
+		return err
+	}
+
+	name, err := git.BranchName(cfg.Git.BranchTemplate, git.BranchIssue{
+		Key:     issue.Key,
+		Project: issue.Fields.Project.Key,
+		Type:    issue.Fields.Type.Name,
+		Summary: issue.Fields.Summary,
+	})
+	if err != nil {
+		return err
+	}
+
+	if git.BranchExists(name) {
+		if err := git.Checkout(name, false); err != nil {
+			return err
+		}
+		fmt.Printf("Switched to existing branch %s\n", name)
+	} else {
+		if err := git.Checkout(name, true); err != nil {
+			return err
+		}
+		fmt.Printf("Created branch %s\n", name)
+	}
+
+	// The branch is what matters most, so Jira updates only warn when they fail
+	if opts.assign {
+		if err := assignToCurrentUser(client, issue.Key); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+	if opts.start {
+		if err := startIssue(cfg, client, issue); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// assignToCurrentUser assigns an issue to the authenticated user
+func assignToCurrentUser(client *jira.Client, key string) error {
+	me, err := client.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+	if err := client.AssignIssue(key, me); err != nil {
+		return err
+	}
+	fmt.Printf("Assigned %s to %s\n", key, me.DisplayName)
+	return nil
+}
+
+// startIssue moves an issue to the configured start status, matching the transition's
+// target status or, failing that, the transition's own name
+func startIssue(cfg *config.Config, client *jira.Client, issue *gojira.Issue) error {
+	status := cfg.Git.StartStatus
+	if status == "" {
+		status = "In Progress"
+	}
+	if issue.Fields.Status != nil && strings.EqualFold(issue.Fields.Status.Name, status) {
+		return nil
+	}
+
+	transitions, err := client.GetTransitions(issue.Key)
+	if err != nil {
+		return err
+	}
+	match := -1
+	for i, t := range transitions {
+		if strings.EqualFold(t.To.Name, status) {
+			match = i
+			break
+		}
+		if match < 0 && strings.EqualFold(t.Name, status) {
+			match = i
+		}
+	}
+	if match < 0 {
+		return fmt.Errorf("no transition to %q available for %s", status, issue.Key)
+	}
+
+	t := transitions[match]
+	if err := client.TransitionIssue(issue.Key, t.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Moved %s to %s\n", issue.Key, t.To.Name)
+	return nil
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+const (
+	keyColumn = "key"
+
+	// maxFixedColumnWidth caps every column except the flexible one when space is tight
+	maxFixedColumnWidth = 20
+	minFlexColumnWidth  = 10
+)
+
+var defaultQueryColumns = []string{keyColumn, "status", "summary"}
+
+// issueColumn is a picker column resolved to a field ID
+type issueColumn struct {
+	ID   string
+	Name string
+}
+
+// resolveColumns maps column names to field IDs, keeping "key" as the issue key
+func resolveColumns(client *jira.Client, names []string) ([]issueColumn, error) {
+	if len(names) == 0 {
+		names = defaultQueryColumns
+	}
+
+	columns := make([]issueColumn, 0, len(names))
+	for _, name := range names {
+		if strings.EqualFold(name, keyColumn) {
+			columns = append(columns, issueColumn{ID: keyColumn, Name: "Key"})
+			continue
+		}
+		field, err := client.ResolveField(name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, issueColumn{ID: field.ID, Name: field.Name})
+	}
+	return columns, nil
+}
+
+// columnFieldIDs returns the field IDs needed to render the columns plus any extra fields
+func columnFieldIDs(client *jira.Client, columns []issueColumn, extra []string) ([]string, error) {
+	ids := []string{}
+	seen := map[string]bool{keyColumn: true}
+	for _, c := range columns {
+		if !seen[c.ID] {
+			seen[c.ID] = true
+			ids = append(ids, c.ID)
+		}
+	}
+	for _, name := range extra {
+		field, err := client.ResolveField(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[field.ID] {
+			seen[field.ID] = true
+			ids = append(ids, field.ID)
+		}
+	}
+	return ids, nil
+}
+
+// issueCell returns the display text of one column for an issue
+func issueCell(issue jira.RawIssue, column issueColumn) string {
+	if column.ID == keyColumn {
+		return issue.Key
+	}
+	text := jira.FormatFieldValue(issue.Fields[column.ID])
+	// Keep each issue on a single line
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// formatIssueLines renders one aligned line per issue, fitting the columns to the terminal width.
+// The summary column (or the last column if there is none) absorbs any shortfall.
+func formatIssueLines(issues []jira.RawIssue, columns []issueColumn) []string {
+	cells := make([][]string, len(issues))
+	widths := make([]int, len(columns))
+	for i, issue := range issues {
+		cells[i] = make([]string, len(columns))
+		for j, c := range columns {
+			cells[i][j] = issueCell(issue, c)
+			if w := runewidth.StringWidth(cells[i][j]); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	flex := len(columns) - 1
+	for j, c := range columns {
+		if c.ID == "summary" {
+			flex = j
+		}
+	}
+	fitColumns(widths, flex, terminalWidth())
+
+	lines := make([]string, len(issues))
+	for i := range issues {
+		parts := make([]string, len(columns))
+		for j := range columns {
+			cell := runewidth.Truncate(cells[i][j], widths[j], "...")
+			if j < len(columns)-1 {
+				cell = runewidth.FillRight(cell, widths[j])
+			}
+			parts[j] = cell
+		}
+		lines[i] = strings.TrimRight(strings.Join(parts, "  "), " ")
+	}
+	return lines
+}
+
+// fitColumns shrinks widths in place so the columns and separators fit in total.
+// Fixed columns are capped first, then the flexible column takes what is left.
+func fitColumns(widths []int, flex, total int) {
+	// Leave room for the fuzzy finder's cursor and scrollbar
+	available := total - 4 - 2*(len(widths)-1)
+
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+	if sum <= available {
+		return
+	}
+
+	used := 0
+	for j := range widths {
+		if j == flex {
+			continue
+		}
+		if widths[j] > maxFixedColumnWidth {
+			widths[j] = maxFixedColumnWidth
+		}
+		used += widths[j]
+	}
+
+	remaining := available - used
+	if remaining < minFlexColumnWidth {
+		remaining = minFlexColumnWidth
+	}
+	if widths[flex] > remaining {
+		widths[flex] = remaining
+	}
+}
+
+// terminalWidth returns the width of the terminal, or 80 if it cannot be determined
+func terminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/git"
 	"github.com/eugenetaranov/jiractl/internal/jira"
 	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
 	"github.com/spf13/cobra"
@@ -13,12 +15,30 @@ import (
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new Jira issue",
-	Long:  `Interactively create a new Jira issue with summary, description, and other fields.`,
-	RunE:  runCreate,
+	Long: `Interactively create a new Jira issue with summary, description, and other fields.
+
+With --branch, a git branch for the new issue is created and checked out as by
+"jiractl branch".`,
+	RunE: runCreate,
 }
 
+var (
+	createFields    []string
+	createAllFields bool
+	createTemplate  string
+	createBranch    bool
+	createStart     bool
+	createAssign    bool
+)
+
 func init() {
 	RootCmd.AddCommand(createCmd)
+	createCmd.Flags().StringArrayVarP(&createFields, "field", "f", nil, `Set a field by name or ID, e.g. -f "Story Points=3" (repeatable)`)
+	createCmd.Flags().BoolVar(&createAllFields, "all-fields", false, "Prompt for optional fields as well as required ones")
+	createCmd.Flags().StringVarP(&createTemplate, "template", "t", "", "Create the issue from a named template")
+	createCmd.Flags().BoolVar(&createBranch, "branch", false, "Create and check out a git branch for the new issue")
+	createCmd.Flags().BoolVar(&createStart, "start", false, "With --branch, move the issue to the start status")
+	createCmd.Flags().BoolVar(&createAssign, "assign", false, "With --branch, assign the issue to yourself")
 }
 
 func loadConfig() (*config.Config, error) {
@@ -29,12 +49,20 @@ func loadConfig() (*config.Config, error) {
 	if cfg.Server == "" || cfg.Project == "" {
 		return nil, fmt.Errorf("not configured, run 'jiractl configure' first")
 	}
+	for _, name := range cfg.ReservedQueries() {
+		fmt.Fprintf(os.Stderr, "Warning: query %q is hidden by the 'jiractl query %s' command, rename it with 'jiractl query rename %s <new>'\n", name, name, name)
+	}
 	return cfg, nil
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
 
+	// Fail before the prompts rather than after the issue exists
+	if createBranch && !git.InWorkTree() {
+		return fmt.Errorf("--branch needs to be run inside a git repository")
+	}
+
 	cfg, err := loadConfig()
 	if err != nil {
 		return err
@@ -45,9 +73,48 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Fill in the template's placeholders first; its values seed the rest of the form
+	var tmpl *config.Template
+	if createTemplate != "" {
+		t := cfg.GetTemplate(createTemplate)
+		if t == nil {
+			return fmt.Errorf("template not found: %s", createTemplate)
+		}
+		tmpl, err = promptTemplate(cfg, t)
+		if err != nil {
+			if err == ErrPromptCancelled {
+				fmt.Println("\nCancelled.")
+				return nil
+			}
+			return err
+		}
+	}
+
+	// Resolve template fields and --field flags up front so typos fail before any prompts.
+	// Flags override template values.
+	rawFields := map[string]string{}
+	if tmpl != nil {
+		for name, value := range tmpl.Fields {
+			rawFields[name] = value
+		}
+	}
+	flagFields, err := parseKeyValueFlags(createFields)
+	if err != nil {
+		return err
+	}
+	for name, value := range flagFields {
+		rawFields[name] = value
+	}
+	fields, err := client.ResolveFieldValues(rawFields)
+	if err != nil {
+		return err
+	}
+
 	// Determine issue type
 	var issueType string
-	if cfg.IssueDefaults.IssueType != "" {
+	if tmpl != nil && tmpl.IssueType != "" {
+		issueType = tmpl.IssueType
+	} else if cfg.IssueDefaults.IssueType != "" {
 		issueType = cfg.IssueDefaults.IssueType
 	} else {
 		// Get available issue types
@@ -75,7 +142,13 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Prompt for summary
-	summary, err := promptText("Summary", true)
+	summaryPrefix := ""
+	summaryLabel := "Summary"
+	if tmpl != nil && tmpl.SummaryPrefix != "" {
+		summaryPrefix = tmpl.SummaryPrefix
+		summaryLabel = fmt.Sprintf("Summary (after %q)", summaryPrefix)
+	}
+	summary, err := promptText(summaryLabel, true)
 	if err != nil {
 		if err == ErrPromptCancelled {
 			fmt.Println("\nCancelled.")
@@ -83,15 +156,41 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		}
 		return err
 	}
+	summary = summaryPrefix + summary
 
-	// Prompt for description
-	description, err := promptMultilineText("Description (optional)")
+	// Prompt for description unless the template provides one
+	var description string
+	if tmpl != nil && tmpl.Description != "" {
+		description = tmpl.Description
+	} else {
+		description, err = promptMultilineText("Description (optional)")
+		if err != nil {
+			if err == ErrPromptCancelled {
+				fmt.Println("\nCancelled.")
+				return nil
+			}
+			return err
+		}
+	}
+
+	// Prompt for the remaining fields the project's create screen asks for
+	var formValues []formValue
+	metas, err := client.CreateMeta(cfg.Project, issueType)
 	if err != nil {
-		if err == ErrPromptCancelled {
-			fmt.Println("\nCancelled.")
-			return nil
+		// Non-fatal: fall back to the basic form
+		fmt.Printf("Warning: could not fetch create metadata: %v\n", err)
+	} else {
+		formValues, err = promptCreateFields(client, metas, fields, createAllFields)
+		if err != nil {
+			if err == ErrPromptCancelled || err == fuzzyfinder.ErrAbort {
+				fmt.Println("\nCancelled.")
+				return nil
+			}
+			return err
+		}
+		for _, v := range formValues {
+			fields[v.ID] = v.Value
 		}
-		return err
 	}
 
 	// Determine epic link
@@ -141,6 +240,9 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	// Confirm creation
 	fmt.Printf("\nCreating issue:\n")
+	if tmpl != nil {
+		fmt.Printf("  Template:    %s\n", tmpl.Name)
+	}
 	fmt.Printf("  Project:     %s\n", cfg.Project)
 	fmt.Printf("  Type:        %s\n", issueType)
 	fmt.Printf("  Summary:     %s\n", summary)
@@ -159,6 +261,18 @@ func runCreate(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  Epic:        %s\n", epicLink)
 		}
 	}
+	if tmpl != nil && len(tmpl.Labels) > 0 {
+		fmt.Printf("  Labels:      %s\n", strings.Join(tmpl.Labels, ", "))
+	}
+	if tmpl != nil && len(tmpl.Components) > 0 {
+		fmt.Printf("  Components:  %s\n", strings.Join(tmpl.Components, ", "))
+	}
+	for _, v := range formValues {
+		fmt.Printf("  %-12s %s\n", v.Name+":", v.Display)
+	}
+	for _, f := range createFields {
+		fmt.Printf("  Field:       %s\n", f)
+	}
 
 	confirmed, err := promptConfirm("Create this issue?")
 	if err != nil {
@@ -170,7 +284,11 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create the issue
-	opts := &jira.CreateIssueOptions{EpicLink: epicLink}
+	opts := &jira.CreateIssueOptions{EpicLink: epicLink, Fields: fields}
+	if tmpl != nil {
+		opts.Labels = tmpl.Labels
+		opts.Components = tmpl.Components
+	}
 	issue, err := client.CreateIssue(cfg.Project, issueType, summary, description, opts)
 	if err != nil {
 		return fmt.Errorf("failed to create issue: %w", err)
@@ -179,5 +297,25 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	fmt.Printf("\nCreated issue: %s\n", issue.Key)
 	fmt.Printf("%s/browse/%s\n", cfg.Server, issue.Key)
 
+	if createBranch {
+		return startBranch(cfg, client, issue.Key, branchOptionsFromFlags(cmd, cfg, createStart, createAssign))
+	}
 	return nil
 }
+
+// promptTemplate prompts for each placeholder in the template and returns the expanded template.
+// ${project} is filled in from the config without prompting.
+func promptTemplate(cfg *config.Config, t *config.Template) (*config.Template, error) {
+	values := map[string]string{"project": cfg.Project}
+	for _, p := range t.Placeholders() {
+		if _, ok := values[p.Name]; ok {
+			continue
+		}
+		value, err := promptTextWithDefault(p.Name, p.Default, !p.HasDefault)
+		if err != nil {
+			return nil, err
+		}
+		values[p.Name] = value
+	}
+	return t.Expand(values), nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit [key]",
+	Short: "Edit fields of an existing issue",
+	Long: `Set fields on an existing issue. Fields can be referenced by name or ID:
+
+  jiractl edit PROJ-123 -f "Story Points=5" -f "Team=Platform"
+
+Without a key, the issue key in the current git branch name is used.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEdit,
+}
+
+var editFields []string
+
+func init() {
+	RootCmd.AddCommand(editCmd)
+	editCmd.Flags().StringArrayVarP(&editFields, "field", "f", nil, `Set a field by name or ID, e.g. -f "Story Points=3" (repeatable)`)
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if len(editFields) == 0 {
+		return fmt.Errorf("nothing to change, pass at least one --field")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	key, err := issueKeyArg(cfg, args)
+	if err != nil {
+		return err
+	}
+
+	rawFields, err := parseKeyValueFlags(editFields)
+	if err != nil {
+		return err
+	}
+	fields, err := client.ResolveFieldValues(rawFields)
+	if err != nil {
+		return err
+	}
+
+	// Check against editmeta so we report a clear error instead of a rejected update
+	metas, err := client.EditMeta(key)
+	if err != nil {
+		return err
+	}
+	editable := make(map[string]bool, len(metas))
+	for _, m := range metas {
+		editable[m.FieldID] = true
+	}
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if !editable[id] {
+			return fmt.Errorf("field %s is not editable on %s", id, key)
+		}
+	}
+
+	if err := client.UpdateIssueFields(key, fields); err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated %s\n", key)
+	fmt.Printf("%s/browse/%s\n", cfg.Server, key)
+	return nil
+}
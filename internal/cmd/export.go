@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/export"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <query|jql>",
+	Short: "Export issues from a saved query or JQL to a file",
+	Long: `Export every issue matching a saved query or a JQL expression to JSON Lines,
+CSV, or Excel-friendly CSV. Fields can be given by name or ID; "*all" exports every field.
+
+  jiractl export "Current Sprint" -o sprint.csv
+  jiractl export "project = PROJ AND type = Bug" -F summary,status,"Story Points" -o bugs.jsonl
+  jiractl export "Critical Bugs" --comments --changelog --format excel -o bugs.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExport,
+}
+
+var (
+	exportFields    []string
+	exportComments  bool
+	exportChangelog bool
+	exportFormat    string
+	exportOutput    string
+	exportParams    []string
+)
+
+var exportDefaultFields = []string{"summary", "status", "assignee", "priority", "created", "updated"}
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringSliceVarP(&exportFields, "fields", "F", exportDefaultFields, `Fields to export by name or ID, or "*all"`)
+	exportCmd.Flags().BoolVar(&exportComments, "comments", false, "Include comments")
+	exportCmd.Flags().BoolVar(&exportChangelog, "changelog", false, "Include the change history")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "Output format: jsonl, csv or excel (default from output file extension)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default stdout)")
+	exportCmd.Flags().StringArrayVarP(&exportParams, "param", "p", nil, `Set a query parameter, e.g. -p label=infra (repeatable)`)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// The argument is a saved query name if one matches, otherwise JQL
+	jql := args[0]
+	if query := cfg.GetQuery(args[0]); query != nil {
+		jql = query.JQL
+	}
+	flagParams, err := parseKeyValueFlags(exportParams)
+	if err != nil {
+		return err
+	}
+	params, err := resolveQueryParams(cfg, jql, flagParams)
+	if err != nil {
+		return err
+	}
+	jql, err = client.ExpandFieldNames(cfg.ExpandJQL(jql, params))
+	if err != nil {
+		return fmt.Errorf("failed to expand query: %w", err)
+	}
+
+	columns, opts, err := exportColumns(client)
+	if err != nil {
+		return err
+	}
+
+	format := exportFormat
+	if format == "" {
+		format = exportFormatFromPath(exportOutput)
+	}
+
+	var out io.Writer = os.Stdout
+	if exportOutput != "" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	writer, err := export.NewWriter(out, format, columns)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = client.SearchAll(jql, opts, func(issues []jira.RawIssue) error {
+		for _, issue := range issues {
+			if err := writer.Write(issue); err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		count += len(issues)
+		if exportOutput != "" {
+			fmt.Fprintf(os.Stderr, "\rExported %d issues...", count)
+		}
+		return nil
+	})
+	if flushErr := writer.Flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("failed to write output: %w", flushErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if exportOutput != "" {
+		fmt.Fprintf(os.Stderr, "\rExported %d issues to %s\n", count, exportOutput)
+	}
+	return nil
+}
+
+// exportColumns builds the output columns, always starting with the issue key,
+// and the search options needed to fetch them
+func exportColumns(client *jira.Client) ([]export.Column, jira.SearchOptions, error) {
+	columns := []export.Column{{ID: export.KeyColumn, Name: "key"}}
+	var opts jira.SearchOptions
+	seen := map[string]bool{}
+	names := map[string]bool{"key": true}
+
+	add := func(id, name string) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		// Field names are not unique in Jira, so disambiguate with the ID
+		if names[name] {
+			name = fmt.Sprintf("%s (%s)", name, id)
+		}
+		names[name] = true
+		columns = append(columns, export.Column{ID: id, Name: name})
+		opts.Fields = append(opts.Fields, id)
+	}
+
+	for _, f := range exportFields {
+		f = strings.TrimSpace(f)
+		if f == "*all" {
+			all, err := client.Fields()
+			if err != nil {
+				return nil, opts, err
+			}
+			sort.Slice(all, func(i, j int) bool {
+				return strings.ToLower(all[i].Name) < strings.ToLower(all[j].Name)
+			})
+			for _, field := range all {
+				add(field.ID, field.Name)
+			}
+			continue
+		}
+
+		field, err := client.ResolveField(f)
+		if err != nil {
+			return nil, opts, err
+		}
+		add(field.ID, field.Name)
+	}
+
+	if exportComments {
+		add("comment", "Comments")
+	}
+	if exportChangelog {
+		columns = append(columns, export.Column{ID: export.ChangelogColumn, Name: "changelog"})
+		opts.Expand = append(opts.Expand, "changelog")
+	}
+
+	return columns, opts, nil
+}
+
+func exportFormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return export.FormatCSV
+	}
+	return export.FormatJSONL
+}
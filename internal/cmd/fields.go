@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var fieldsCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "Inspect Jira fields",
+	Long:  `List system and custom fields and look up their IDs, types, and allowed values.`,
+}
+
+var fieldsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List fields",
+	Args:  cobra.NoArgs,
+	RunE:  runFieldsList,
+}
+
+var fieldsShowCmd = &cobra.Command{
+	Use:   "show <name|id>",
+	Short: "Show details for a field",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runFieldsShow,
+}
+
+var (
+	fieldsCustomOnly bool
+	fieldsRefresh    bool
+	fieldsIssueType  string
+)
+
+func init() {
+	RootCmd.AddCommand(fieldsCmd)
+	fieldsCmd.AddCommand(fieldsListCmd)
+	fieldsCmd.AddCommand(fieldsShowCmd)
+
+	fieldsCmd.PersistentFlags().BoolVar(&fieldsRefresh, "refresh", false, "Refetch field metadata instead of using the cache")
+	fieldsListCmd.Flags().BoolVar(&fieldsCustomOnly, "custom", false, "Only list custom fields")
+	fieldsShowCmd.Flags().StringVarP(&fieldsIssueType, "type", "t", "", "Issue type to show create metadata and allowed values for")
+}
+
+func newFieldsClient() (*jira.Client, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if fieldsRefresh {
+		if err := client.RefreshFieldCache(); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func runFieldsList(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	client, err := newFieldsClient()
+	if err != nil {
+		return err
+	}
+
+	fields, err := client.Fields()
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return strings.ToLower(fields[i].Name) < strings.ToLower(fields[j].Name)
+	})
+
+	fmt.Printf("%-22s %-20s %s\n", "ID", "TYPE", "NAME")
+	for _, f := range fields {
+		if fieldsCustomOnly && !f.Custom {
+			continue
+		}
+		fmt.Printf("%-22s %-20s %s\n", f.ID, jira.FieldTypeName(f.Schema), f.Name)
+	}
+
+	return nil
+}
+
+func runFieldsShow(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	client, err := newFieldsClient()
+	if err != nil {
+		return err
+	}
+
+	field, err := client.ResolveField(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Name:        %s\n", field.Name)
+	fmt.Printf("ID:          %s\n", field.ID)
+	fmt.Printf("Type:        %s\n", jira.FieldTypeName(field.Schema))
+	if field.Schema.Custom != "" {
+		fmt.Printf("Custom type: %s\n", field.Schema.Custom)
+	}
+	if len(field.ClauseNames) > 0 {
+		fmt.Printf("JQL names:   %s\n", strings.Join(field.ClauseNames, ", "))
+	}
+
+	if fieldsIssueType == "" {
+		return nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	metas, err := client.CreateMeta(cfg.Project, fieldsIssueType)
+	if err != nil {
+		return err
+	}
+
+	for _, meta := range metas {
+		if meta.FieldID != field.ID {
+			continue
+		}
+		fmt.Printf("Required:    %v (%s %s)\n", meta.Required, cfg.Project, fieldsIssueType)
+		if len(meta.AllowedValues) > 0 {
+			fmt.Println("\nAllowed values:")
+			for _, v := range meta.AllowedValues {
+				fmt.Printf("  %s\n", v.Label())
+			}
+		}
+		return nil
+	}
+
+	fmt.Printf("\nNot available when creating %s issues in %s\n", fieldsIssueType, cfg.Project)
+	return nil
+}
@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"fmt"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+	"github.com/spf13/cobra"
+)
+
+var filtersCmd = &cobra.Command{
+	Use:   "filters",
+	Short: "Work with Jira server-side filters",
+	Long: `List your favourite and owned Jira filters, import them as saved queries,
+keep imported queries in sync, or publish a saved query as a shared filter.
+
+Imported queries remember their filter with filter_id. Run a filter without
+importing it with: jiractl query --filter <id>`,
+}
+
+var filtersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List your favourite and owned filters",
+	Args:  cobra.NoArgs,
+	RunE:  runFiltersList,
+}
+
+var filtersImportCmd = &cobra.Command{
+	Use:   "import [id...]",
+	Short: "Import filters as saved queries",
+	Long: `Import server-side filters as saved queries. Without arguments, pick the
+filters to import from your favourite and owned filters.`,
+	RunE: runFiltersImport,
+}
+
+var filtersSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Update imported queries from their filters",
+	Args:  cobra.NoArgs,
+	RunE:  runFiltersSync,
+}
+
+var filtersPublishCmd = &cobra.Command{
+	Use:   "publish <query>",
+	Short: "Publish a saved query as a server-side filter",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runFiltersPublish,
+}
+
+var (
+	filtersImportName  string
+	filtersSyncPrune   bool
+	filtersShare       string
+	filtersFavourite   bool
+	filtersDescription string
+)
+
+func init() {
+	RootCmd.AddCommand(filtersCmd)
+	filtersCmd.AddCommand(filtersListCmd)
+	filtersCmd.AddCommand(filtersImportCmd)
+	filtersCmd.AddCommand(filtersSyncCmd)
+	filtersCmd.AddCommand(filtersPublishCmd)
+
+	filtersImportCmd.Flags().StringVar(&filtersImportName, "name", "", "Query name (defaults to the filter name; single filter only)")
+	filtersSyncCmd.Flags().BoolVar(&filtersSyncPrune, "prune", false, "Remove queries whose filter no longer exists")
+	filtersPublishCmd.Flags().StringVar(&filtersShare, "share", jira.ShareProject, "Share with: none, project or authenticated")
+	filtersPublishCmd.Flags().BoolVar(&filtersFavourite, "favourite", false, "Mark the filter as a favourite")
+	filtersPublishCmd.Flags().StringVar(&filtersDescription, "description", "", "Filter description")
+}
+
+func runFiltersList(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	filters, err := client.GetFilters()
+	if err != nil {
+		return err
+	}
+
+	if len(filters) == 0 {
+		fmt.Println("No filters found.")
+		return nil
+	}
+
+	idWidth, nameWidth := 0, 0
+	for _, f := range filters {
+		idWidth = max(idWidth, len(f.ID))
+		nameWidth = max(nameWidth, len(f.Name))
+	}
+	for _, f := range filters {
+		fav := " "
+		if f.Favourite {
+			fav = "*"
+		}
+		line := fmt.Sprintf("%-*s %s %-*s  %s", idWidth, f.ID, fav, nameWidth, f.Name, f.Owner.DisplayName)
+		if q := cfg.GetQueryByFilter(f.ID); q != nil {
+			line += fmt.Sprintf("  (query: %s)", q.Name)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runFiltersImport(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if filtersImportName != "" && len(args) != 1 {
+		return fmt.Errorf("--name requires exactly one filter ID")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	var filters []*gojira.Filter
+	if len(args) == 0 {
+		filters, err = selectFilters(client)
+		if err != nil {
+			if err == fuzzyfinder.ErrAbort {
+				fmt.Println("\nCancelled.")
+				return nil
+			}
+			return err
+		}
+	} else {
+		for _, id := range args {
+			f, err := client.GetFilter(id)
+			if err != nil {
+				return err
+			}
+			filters = append(filters, f)
+		}
+	}
+
+	for _, f := range filters {
+		name := f.Name
+		if filtersImportName != "" {
+			name = filtersImportName
+		}
+		if err := config.CheckQueryName(name); err != nil {
+			fmt.Printf("Skipping filter %s: %v (use --name)\n", f.ID, err)
+			continue
+		}
+
+		if existing := cfg.GetQuery(name); existing != nil {
+			if existing.FilterID != f.ID {
+				fmt.Printf("Skipping filter %s: query %q already exists (use --name)\n", f.ID, name)
+				continue
+			}
+			updated := *existing
+			updated.JQL = f.Jql
+			if err := cfg.UpdateQuery(name, linkFilter(updated, f)); err != nil {
+				return fmt.Errorf("failed to save query: %w", err)
+			}
+			fmt.Printf("Query updated: %s\n", name)
+			continue
+		}
+
+		query := linkFilter(config.Query{Name: name, JQL: f.Jql}, f)
+		if err := cfg.AddQuery(query); err != nil {
+			return fmt.Errorf("failed to save query: %w", err)
+		}
+		fmt.Printf("Query imported: %s (filter %s)\n", name, f.ID)
+	}
+	return nil
+}
+
+// selectFilters lets the user pick filters that have not been imported yet
+func selectFilters(client *jira.Client) ([]*gojira.Filter, error) {
+	filters, err := client.GetFilters()
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("no filters found")
+	}
+
+	items := make([]string, len(filters))
+	for i, f := range filters {
+		items[i] = fmt.Sprintf("%s  %s", f.ID, f.Name)
+	}
+
+	indices, err := fzfSelectMulti(items, "Select filters to import (Tab to select)")
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]*gojira.Filter, len(indices))
+	for i, idx := range indices {
+		selected[i] = filters[idx]
+	}
+	return selected, nil
+}
+
+func runFiltersSync(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	filters, err := client.GetFilters()
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*gojira.Filter, len(filters))
+	for _, f := range filters {
+		byID[f.ID] = f
+	}
+
+	// Copy the list, as queries are edited in place while iterating
+	queries := append([]config.Query(nil), cfg.Queries...)
+
+	synced := 0
+	for _, q := range queries {
+		if q.FilterID == "" {
+			continue
+		}
+		synced++
+
+		f, ok := byID[q.FilterID]
+		if !ok {
+			// Filters shared with the user are neither owned nor favourites
+			f, err = client.GetFilter(q.FilterID)
+			if err != nil {
+				if !filtersSyncPrune {
+					fmt.Printf("%s: filter %s unavailable: %v\n", q.Name, q.FilterID, err)
+					continue
+				}
+				if err := cfg.RemoveQuery(q.Name); err != nil {
+					return fmt.Errorf("failed to remove query: %w", err)
+				}
+				fmt.Printf("%s: removed (filter %s no longer exists)\n", q.Name, q.FilterID)
+				continue
+			}
+		}
+
+		// Queries linked before filter_jql was recorded: the filter of a published query
+		// holds its JQL expanded, which is not a change on the server
+		if q.FilterJQL == "" && q.JQL != f.Jql {
+			if jql, err := client.ExpandFieldNames(cfg.ExpandJQL(q.JQL, nil)); err == nil && jql == f.Jql {
+				if err := cfg.UpdateQuery(q.Name, linkFilter(q, f)); err != nil {
+					return fmt.Errorf("failed to save query: %w", err)
+				}
+				continue
+			}
+		}
+
+		updated, changed := syncFromFilter(q, f)
+		if !changed {
+			continue
+		}
+		if err := cfg.UpdateQuery(q.Name, updated); err != nil {
+			return fmt.Errorf("failed to save query: %w", err)
+		}
+		fmt.Printf("%s: updated\n", q.Name)
+	}
+
+	if synced == 0 {
+		fmt.Println("No queries are linked to filters. Import some with: jiractl filters import")
+		return nil
+	}
+	fmt.Printf("Synced %d queries.\n", synced)
+	return nil
+}
+
+func runFiltersPublish(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	query := cfg.GetQuery(args[0])
+	if query == nil {
+		return fmt.Errorf("query not found: %s", args[0])
+	}
+	if query.FilterID != "" {
+		return fmt.Errorf("query %s is already linked to filter %s", query.Name, query.FilterID)
+	}
+
+	// Filters are stored as plain JQL, so every parameter needs a fixed value
+	for _, p := range cfg.QueryParams(query.JQL) {
+		if !p.HasDefault {
+			return fmt.Errorf("cannot publish query with required parameter ${%s}", p.Name)
+		}
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	jql, err := client.ExpandFieldNames(cfg.ExpandJQL(query.JQL, nil))
+	if err != nil {
+		return fmt.Errorf("failed to expand query: %w", err)
+	}
+
+	opts := &jira.CreateFilterOptions{
+		Description: filtersDescription,
+		Favourite:   filtersFavourite,
+		Share:       filtersShare,
+		ProjectKey:  cfg.Project,
+	}
+	if opts.Share == jira.ShareProject && opts.ProjectKey == "" {
+		return fmt.Errorf("sharing with a project requires project in config; use --share none or authenticated")
+	}
+
+	filter, err := client.CreateFilter(query.Name, jql, opts)
+	if err != nil {
+		return err
+	}
+
+	// The query keeps its parameters; the filter holds them expanded
+	if filter.Jql == "" {
+		filter.Jql = jql
+	}
+	if err := cfg.UpdateQuery(query.Name, linkFilter(*query, filter)); err != nil {
+		return fmt.Errorf("failed to link query to filter: %w", err)
+	}
+
+	fmt.Printf("Filter created: %s (%s)\n", filter.ID, filter.Name)
+	if filter.ViewURL != "" {
+		fmt.Println(filter.ViewURL)
+	}
+	return nil
+}
+
+// linkFilter links a query to a filter and records the filter's current JQL
+func linkFilter(q config.Query, f *gojira.Filter) config.Query {
+	q.FilterID = f.ID
+	q.FilterJQL = f.Jql
+	return q
+}
+
+// syncFromFilter returns the query updated from its filter, and whether the filter changed.
+// Only changes on the server replace the query's JQL, so a published query keeps its
+// parameters and field names, and local edits survive until the filter itself is edited.
+func syncFromFilter(q config.Query, f *gojira.Filter) (config.Query, bool) {
+	if f.Jql == q.FilterJQL || (q.FilterJQL == "" && f.Jql == q.JQL) {
+		return q, false
+	}
+	q.JQL = f.Jql
+	return linkFilter(q, f), true
+}
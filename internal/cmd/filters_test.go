@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/config"
+)
+
+func TestSyncFromFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       config.Query
+		filter      gojira.Filter
+		wantChanged bool
+		wantJQL     string
+	}{
+		{
+			name:    "published query is unchanged",
+			query:   config.Query{Name: "Mine", JQL: "project = ${project} AND cf[Team] = ${team:core}", FilterID: "100", FilterJQL: "project = PROJ AND cf[10042] = core"},
+			filter:  gojira.Filter{ID: "100", Jql: "project = PROJ AND cf[10042] = core"},
+			wantJQL: "project = ${project} AND cf[Team] = ${team:core}",
+		},
+		{
+			name:        "filter edited on the server",
+			query:       config.Query{Name: "Mine", JQL: "project = ${project}", FilterID: "100", FilterJQL: "project = PROJ"},
+			filter:      gojira.Filter{ID: "100", Jql: "project = PROJ AND type = Bug"},
+			wantChanged: true,
+			wantJQL:     "project = PROJ AND type = Bug",
+		},
+		{
+			name:    "local edits are kept while the filter is unchanged",
+			query:   config.Query{Name: "Bugs", JQL: "type = Bug AND priority = High", FilterID: "100", FilterJQL: "type = Bug"},
+			filter:  gojira.Filter{ID: "100", Jql: "type = Bug"},
+			wantJQL: "type = Bug AND priority = High",
+		},
+		{
+			name:    "imported before filter_jql was recorded",
+			query:   config.Query{Name: "Bugs", JQL: "type = Bug", FilterID: "100"},
+			filter:  gojira.Filter{ID: "100", Jql: "type = Bug"},
+			wantJQL: "type = Bug",
+		},
+		{
+			name:        "imported before filter_jql was recorded and edited since",
+			query:       config.Query{Name: "Bugs", JQL: "type = Bug", FilterID: "100"},
+			filter:      gojira.Filter{ID: "100", Jql: "type = Bug AND resolution = Unresolved"},
+			wantChanged: true,
+			wantJQL:     "type = Bug AND resolution = Unresolved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := syncFromFilter(tt.query, &tt.filter)
+			if changed != tt.wantChanged {
+				t.Errorf("syncFromFilter() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if got.JQL != tt.wantJQL {
+				t.Errorf("syncFromFilter() JQL = %q, want %q", got.JQL, tt.wantJQL)
+			}
+			if changed && got.FilterJQL != tt.filter.Jql {
+				t.Errorf("syncFromFilter() FilterJQL = %q, want %q", got.FilterJQL, tt.filter.Jql)
+			}
+		})
+	}
+}
+
+// TestPublishSyncRoundTrip links a query with parameters to the filter published from it,
+// then syncs it from the saved config file
+func TestPublishSyncRoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	original := "project = ${project} AND cf[Team] = ${team:core} AND updated >= ${today}"
+	content := "project = \"PROJ\"\n\n[[queries]]\nname = \"Team\"\njql = '" + original + "'\n"
+	if err := os.WriteFile(filepath.Join(home, config.ConfigFileName), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// publish sends the expanded JQL and links the query to the new filter
+	filter := &gojira.Filter{ID: "100", Jql: "project = PROJ AND cf[10042] = core AND updated >= 2026-10-18"}
+	if err := cfg.UpdateQuery("Team", linkFilter(*cfg.GetQuery("Team"), filter)); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := *cfg.GetQuery("Team")
+	if q.JQL != original || q.FilterID != "100" {
+		t.Fatalf("published query = %+v, want JQL %q linked to filter 100", q, original)
+	}
+	if _, changed := syncFromFilter(q, filter); changed {
+		t.Error("sync right after publish changed the query")
+	}
+
+	edited := &gojira.Filter{ID: "100", Jql: "project = PROJ AND cf[10042] = web"}
+	synced, changed := syncFromFilter(q, edited)
+	if !changed || synced.JQL != edited.Jql {
+		t.Fatalf("sync after a server edit = %+v (changed %v), want JQL %q", synced, changed, edited.Jql)
+	}
+	if _, changed := syncFromFilter(synced, edited); changed {
+		t.Error("second sync changed the query again")
+	}
+}
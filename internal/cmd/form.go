@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+const textareaSchema = "com.atlassian.jira.plugin.system.customfieldtypes:textarea"
+
+// formSkipFields are prompted for by runCreate itself or filled in by Jira
+var formSkipFields = map[string]bool{
+	"project":     true,
+	"issuetype":   true,
+	"summary":     true,
+	"description": true,
+	"reporter":    true,
+}
+
+// formValue is a field value collected by the create form
+type formValue struct {
+	ID      string
+	Name    string
+	Value   interface{}
+	Display string
+}
+
+// promptCreateFields prompts for createmeta fields not handled elsewhere in runCreate.
+// Required fields without a default are always prompted; optional ones only when all is set.
+// Fields already present in preset (e.g. from --field flags) are skipped.
+func promptCreateFields(client *jira.Client, metas []jira.FieldMeta, preset map[string]interface{}, all bool) ([]formValue, error) {
+	var values []formValue
+	for _, meta := range metas {
+		if formSkipFields[meta.FieldID] || meta.IsEpicLink() {
+			continue
+		}
+		if _, ok := preset[meta.FieldID]; ok {
+			continue
+		}
+		if !all && (!meta.Required || meta.HasDefaultValue) {
+			continue
+		}
+
+		value, display, err := promptFieldValue(client, meta)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			values = append(values, formValue{ID: meta.FieldID, Name: meta.Name, Value: value, Display: display})
+		}
+	}
+	return values, nil
+}
+
+// promptFieldValue prompts for a single field using a widget suited to its schema.
+// It returns a nil value when an optional field is left empty.
+func promptFieldValue(client *jira.Client, meta jira.FieldMeta) (interface{}, string, error) {
+	label := meta.Name
+	if !meta.Required {
+		label += " (optional)"
+	}
+
+	multi := meta.Schema.Type == "array"
+	itemType := meta.Schema.Type
+	if multi {
+		itemType = meta.Schema.Items
+	}
+
+	switch {
+	case len(meta.AllowedValues) > 0:
+		return promptAllowedValue(meta, label, multi)
+	case itemType == "user":
+		return promptUserValue(client, meta, label, multi)
+	case meta.Schema.Custom == textareaSchema:
+		for {
+			text, err := promptMultilineText(label)
+			if err != nil {
+				return nil, "", err
+			}
+			if text != "" {
+				return text, fmt.Sprintf("(%d lines)", len(strings.Split(text, "\n"))), nil
+			}
+			if !meta.Required {
+				return nil, "", nil
+			}
+			fmt.Println("This field is required")
+		}
+	}
+
+	// Everything else is typed in and validated against the schema
+	hint := ""
+	switch {
+	case multi:
+		hint = " (comma-separated)"
+	case itemType == "date":
+		hint = " (YYYY-MM-DD)"
+	case itemType == "datetime":
+		hint = " (YYYY-MM-DD HH:MM)"
+	case itemType == "number":
+		hint = " (number)"
+	}
+
+	for {
+		raw, err := promptText(label+hint, meta.Required)
+		if err != nil {
+			return nil, "", err
+		}
+		if raw == "" {
+			return nil, "", nil
+		}
+		value, err := jira.FieldValue(meta.Schema, raw)
+		if err != nil {
+			fmt.Printf("Invalid value: %v\n", err)
+			continue
+		}
+		return value, raw, nil
+	}
+}
+
+// promptAllowedValue offers the field's allowed values in a fuzzy finder
+func promptAllowedValue(meta jira.FieldMeta, label string, multi bool) (interface{}, string, error) {
+	items := make([]string, 0, len(meta.AllowedValues)+1)
+	offset := 0
+	if !meta.Required {
+		items = append(items, "(None)")
+		offset = 1
+	}
+	for _, v := range meta.AllowedValues {
+		items = append(items, v.Label())
+	}
+
+	if !multi {
+		idx, err := fzfSelect(items, "Select "+label)
+		if err != nil {
+			return nil, "", err
+		}
+		if idx < offset {
+			return nil, "", nil
+		}
+		v := meta.AllowedValues[idx-offset]
+		return map[string]string{"id": v.ID}, v.Label(), nil
+	}
+
+	idxs, err := fzfSelectMulti(items, "Select "+label+" (Tab to select multiple)")
+	if err != nil {
+		return nil, "", err
+	}
+	var values []interface{}
+	var labels []string
+	for _, idx := range idxs {
+		if idx < offset {
+			continue
+		}
+		v := meta.AllowedValues[idx-offset]
+		values = append(values, map[string]string{"id": v.ID})
+		labels = append(labels, v.Label())
+	}
+	if len(values) == 0 {
+		return nil, "", nil
+	}
+	return values, strings.Join(labels, ", "), nil
+}
+
+// promptUserValue searches users by name and lets the user pick from the matches
+func promptUserValue(client *jira.Client, meta jira.FieldMeta, label string, multi bool) (interface{}, string, error) {
+	for {
+		query, err := promptText(label+" (search users)", meta.Required)
+		if err != nil {
+			return nil, "", err
+		}
+		if query == "" {
+			return nil, "", nil
+		}
+
+		users, err := client.SearchUsers(query)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(users) == 0 {
+			fmt.Println("No users found")
+			continue
+		}
+
+		items := make([]string, len(users))
+		for i, u := range users {
+			items[i] = u.DisplayName
+			if u.EmailAddress != "" {
+				items[i] += " <" + u.EmailAddress + ">"
+			}
+		}
+
+		if !multi {
+			idx, err := fzfSelect(items, "Select "+meta.Name)
+			if err != nil {
+				return nil, "", err
+			}
+			return map[string]string{"accountId": users[idx].AccountID}, users[idx].DisplayName, nil
+		}
+
+		idxs, err := fzfSelectMulti(items, "Select "+meta.Name+" (Tab to select multiple)")
+		if err != nil {
+			return nil, "", err
+		}
+		values := make([]interface{}, len(idxs))
+		names := make([]string, len(idxs))
+		for i, idx := range idxs {
+			values[i] = map[string]string{"accountId": users[idx].AccountID}
+			names[i] = users[idx].DisplayName
+		}
+		return values, strings.Join(names, ", "), nil
+	}
+}
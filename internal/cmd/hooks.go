@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/cache"
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/git"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage git hooks that add and check issue keys in commit messages",
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the commit message hooks in the current repository",
+	Long: `Install prepare-commit-msg and commit-msg hooks in the current repository.
+
+prepare-commit-msg starts each commit message with the issue key from the current
+branch name (see key_pattern in the [git] config section), unless the message already
+mentions it. commit-msg can also enforce that messages reference issues:
+
+  jiractl hooks install                              # Only insert the branch key
+  jiractl hooks install --require-key                # Reject messages without an issue key
+  jiractl hooks install --require-key --check-issues # ...or with missing or closed issues
+
+Issue lookups are cached for an hour so most commits don't wait for Jira. When Jira
+can't be reached, the last known state of the issue is used, and unknown issues are
+let through with a warning.`,
+	Args: cobra.NoArgs,
+	RunE: runHooksInstall,
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the commit message hooks from the current repository",
+	Args:  cobra.NoArgs,
+	RunE:  runHooksUninstall,
+}
+
+// hooksRunCmd is what the installed hook scripts call
+var hooksRunCmd = &cobra.Command{
+	Use:    "run <hook> <args>...",
+	Short:  "Run a git hook",
+	Hidden: true,
+	Args:   cobra.MinimumNArgs(2),
+	RunE:   runHook,
+}
+
+var (
+	hooksForce       bool
+	hooksRequireKey  bool
+	hooksCheckIssues bool
+)
+
+// hookNames are the hooks installed, in the order git runs them
+var hookNames = []string{"prepare-commit-msg", "commit-msg"}
+
+// hookMarker identifies hook scripts written by jiractl
+const hookMarker = "# Installed by jiractl"
+
+// commitIssueTTL is how long an issue lookup is trusted before asking Jira again
+const commitIssueTTL = time.Hour
+
+func init() {
+	RootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksRunCmd)
+	hooksInstallCmd.Flags().BoolVarP(&hooksForce, "force", "f", false, "Replace existing hooks not installed by jiractl")
+	hooksInstallCmd.Flags().BoolVar(&hooksRequireKey, "require-key", false, "Reject commit messages without an issue key")
+	hooksInstallCmd.Flags().BoolVar(&hooksCheckIssues, "check-issues", false, "Reject commit messages referencing missing or closed issues")
+	hooksRunCmd.Flags().BoolVar(&hooksRequireKey, "require-key", false, "Reject commit messages without an issue key")
+	hooksRunCmd.Flags().BoolVar(&hooksCheckIssues, "check-issues", false, "Reject commit messages referencing missing or closed issues")
+}
+
+func runHooksInstall(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	dir, err := git.HooksDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	// Check every hook before writing any, so a conflict leaves nothing half installed
+	for _, name := range hookNames {
+		path := filepath.Join(dir, name)
+		installed, err := jiractlHook(path)
+		if err != nil {
+			return err
+		}
+		if !installed && fileExists(path) && !hooksForce {
+			return fmt.Errorf("%s already exists; use --force to replace it", path)
+		}
+	}
+
+	var flags string
+	if hooksRequireKey {
+		flags += " --require-key"
+	}
+	if hooksCheckIssues {
+		flags += " --check-issues"
+	}
+	for _, name := range hookNames {
+		script := fmt.Sprintf(`#!/bin/sh
+%s; remove with "jiractl hooks uninstall"
+command -v jiractl >/dev/null 2>&1 || exit 0
+exec jiractl hooks run %s%s "$@"
+`, hookMarker, name, flags)
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+			return fmt.Errorf("failed to write hook: %w", err)
+		}
+		// WriteFile keeps the mode of an existing file
+		if err := os.Chmod(path, 0o755); err != nil {
+			return fmt.Errorf("failed to write hook: %w", err)
+		}
+		fmt.Printf("Installed %s\n", path)
+	}
+	return nil
+}
+
+func runHooksUninstall(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	dir, err := git.HooksDir()
+	if err != nil {
+		return err
+	}
+
+	removed := 0
+	for _, name := range hookNames {
+		path := filepath.Join(dir, name)
+		installed, err := jiractlHook(path)
+		if err != nil {
+			return err
+		}
+		if !installed {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove hook: %w", err)
+		}
+		fmt.Printf("Removed %s\n", path)
+		removed++
+	}
+	if removed == 0 {
+		fmt.Println("No jiractl hooks installed.")
+	}
+	return nil
+}
+
+// jiractlHook reports whether the hook at path was installed by jiractl
+func jiractlHook(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read hook: %w", err)
+	}
+	return strings.Contains(string(data), hookMarker), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func runHook(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	// Hooks work without a configured server until issues need checking
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	switch args[0] {
+	case "prepare-commit-msg":
+		var source string
+		if len(args) > 2 {
+			source = args[2]
+		}
+		return prepareCommitMsg(cfg, args[1], source)
+	case "commit-msg":
+		return checkCommitMsg(cfg, args[1])
+	}
+	return fmt.Errorf("unknown hook %q", args[0])
+}
+
+// prepareCommitMsg starts the commit message with the branch's issue key. source is
+// what git says the message came from; merges, squashes and reused messages are left alone.
+func prepareCommitMsg(cfg *config.Config, path, source string) error {
+	if source == "merge" || source == "squash" || source == "commit" {
+		return nil
+	}
+	key, err := git.BranchIssueKey(cfg.Git.KeyPattern)
+	if err != nil {
+		// Not every branch is about an issue
+		return nil
+	}
+	key = strings.ToUpper(key)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read commit message: %w", err)
+	}
+	for _, k := range git.MessageIssueKeys(stripCommitComments(string(data))) {
+		if k == key {
+			return nil
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(key+" "+string(data)), 0o644); err != nil {
+		return fmt.Errorf("failed to write commit message: %w", err)
+	}
+	return nil
+}
+
+// checkCommitMsg enforces the --require-key and --check-issues rules on a commit message
+func checkCommitMsg(cfg *config.Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read commit message: %w", err)
+	}
+	message := strings.TrimSpace(stripCommitComments(string(data)))
+	keys := git.MessageIssueKeys(message)
+
+	// The key prepare-commit-msg added is all that's left when the message was left empty
+	if len(keys) == 1 && message == keys[0] {
+		return fmt.Errorf("aborting commit due to empty commit message")
+	}
+	if message == "" || git.Merging() {
+		return nil
+	}
+
+	if len(keys) == 0 {
+		if hooksRequireKey {
+			return fmt.Errorf("commit message must reference an issue key, e.g. PROJ-123")
+		}
+		return nil
+	}
+	if !hooksCheckIssues {
+		return nil
+	}
+
+	if cfg.Server == "" {
+		return fmt.Errorf("not configured, run 'jiractl configure' first")
+	}
+	checker := &issueChecker{cfg: cfg}
+	for _, key := range keys {
+		issue, err := checker.lookup(key)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not check %s: %v\n", key, err)
+			continue
+		}
+		if !issue.Exists {
+			return fmt.Errorf("issue %s does not exist", key)
+		}
+		if issue.Done {
+			return fmt.Errorf("issue %s is closed (%s)", key, issue.Status)
+		}
+	}
+	return nil
+}
+
+// stripCommitComments removes the lines git strips from a commit message: comments and
+// everything below the scissors line of "git commit --verbose"
+func stripCommitComments(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "# ") && strings.Contains(line, ">8") {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commitIssue is the cached state of an issue referenced by a commit
+type commitIssue struct {
+	Exists bool   `json:"exists"`
+	Done   bool   `json:"done"`
+	Status string `json:"status,omitempty"`
+}
+
+// issueChecker looks up the issues referenced by commits, creating the client on first use
+type issueChecker struct {
+	cfg    *config.Config
+	client *jira.Client
+}
+
+// lookup returns the state of an issue from the cache or, when the entry is stale, from
+// Jira. If Jira can't be reached, a stale entry is used. Missing issues are not cached
+// so that a newly created issue is found straight away.
+func (c *issueChecker) lookup(key string) (*commitIssue, error) {
+	var issue commitIssue
+	name := "commit_issue_" + key
+	if ok, _ := cache.Load(c.cfg.Server, name, commitIssueTTL, &issue); ok {
+		return &issue, nil
+	}
+
+	if err := c.fetch(key, &issue); err != nil {
+		if ok, _ := cache.Load(c.cfg.Server, name, 0, &issue); ok {
+			return &issue, nil
+		}
+		return nil, err
+	}
+	if issue.Exists {
+		_ = cache.Save(c.cfg.Server, name, issue)
+	}
+	return &issue, nil
+}
+
+func (c *issueChecker) fetch(key string, issue *commitIssue) error {
+	if c.client == nil {
+		client, err := jira.NewClient(c.cfg)
+		if err != nil {
+			return err
+		}
+		c.client = client
+	}
+
+	found, err := c.client.GetIssue(key)
+	if err != nil {
+		var apiErr *jira.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			*issue = commitIssue{}
+			return nil
+		}
+		return err
+	}
+
+	*issue = commitIssue{Exists: true}
+	if found.Fields != nil && found.Fields.Status != nil {
+		issue.Status = found.Fields.Status.Name
+		issue.Done = found.Fields.Status.StatusCategory.Key == "done"
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/eugenetaranov/jiractl/internal/importer"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Create issues in bulk from a CSV, YAML or JSON file",
+	Long: `Create many issues in one run from a CSV, YAML or JSON file.
+
+Each record may set ref, parent, project, type, summary, description, labels and
+components; any other column or key is treated as a field name or ID. Records link
+to their parent by ref (or an existing issue key), so epics, stories and sub-tasks
+can be created together. Created keys are appended to a results file, and re-running
+the same import skips records that were already created.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+var (
+	importDryRun      bool
+	importConcurrency int
+	importResults     string
+	importFormat      string
+	importMappings    []string
+)
+
+func init() {
+	RootCmd.AddCommand(importCmd)
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Validate the file against create metadata without creating issues")
+	importCmd.Flags().IntVarP(&importConcurrency, "concurrency", "c", 4, "Maximum number of issues created in parallel")
+	importCmd.Flags().StringVar(&importResults, "results", "", "Results file for resuming (default <file>.results.jsonl)")
+	importCmd.Flags().StringVar(&importFormat, "format", "", "Input format: csv, yaml or json (default from file extension)")
+	importCmd.Flags().StringArrayVarP(&importMappings, "map", "m", nil, `Map an input column to a field, e.g. -m "Points=Story Points" (repeatable)`)
+}
+
+// importJob is a validated record ready to be created
+type importJob struct {
+	record importer.Record
+	fields map[string]interface{}
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	mapping, err := parseKeyValueFlags(importMappings)
+	if err != nil {
+		return err
+	}
+
+	records, err := importer.ReadFile(args[0], importFormat, mapping)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		fmt.Println("No issues to import.")
+		return nil
+	}
+
+	for i := range records {
+		if records[i].Project == "" {
+			records[i].Project = cfg.Project
+		}
+		if records[i].IssueType == "" {
+			records[i].IssueType = cfg.IssueDefaults.IssueType
+		}
+	}
+
+	levels, err := importer.Levels(records)
+	if err != nil {
+		return err
+	}
+
+	// Validate everything before creating anything
+	jobs := make([]importJob, len(records))
+	var problems []string
+	for i, rec := range records {
+		fields, errs := validateImportRecord(client, rec)
+		for _, e := range errs {
+			problems = append(problems, fmt.Sprintf("row %d (%s): %v", rec.Row, rec.Ref, e))
+		}
+		jobs[i] = importJob{record: rec, fields: fields}
+	}
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Println(p)
+		}
+		return fmt.Errorf("%d problem(s) found in %s", len(problems), args[0])
+	}
+
+	if importDryRun {
+		for depth, level := range levels {
+			for _, i := range level {
+				rec := records[i]
+				parent := ""
+				if rec.Parent != "" {
+					parent = "  (parent: " + rec.Parent + ")"
+				}
+				fmt.Printf("%s%-10s %-8s %s%s\n", strings.Repeat("  ", depth), rec.Ref, rec.IssueType, rec.Summary, parent)
+			}
+		}
+		fmt.Printf("\n%d issue(s) valid, nothing created (dry run).\n", len(records))
+		return nil
+	}
+
+	resultsPath := importResults
+	if resultsPath == "" {
+		resultsPath = args[0] + ".results.jsonl"
+	}
+	keys, err := importer.LoadResults(resultsPath)
+	if err != nil {
+		return err
+	}
+	writer, err := importer.NewResultWriter(resultsPath)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	byRef := make(map[string]importer.Record, len(records))
+	for _, rec := range records {
+		byRef[rec.Ref] = rec
+	}
+
+	if importConcurrency < 1 {
+		importConcurrency = 1
+	}
+
+	var (
+		mu      sync.Mutex
+		created int
+		skipped int
+		failed  []string
+	)
+
+	for _, level := range levels {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, importConcurrency)
+
+		for _, i := range level {
+			job := jobs[i]
+			rec := job.record
+
+			mu.Lock()
+			key, done := keys[rec.Ref]
+			mu.Unlock()
+			if done {
+				fmt.Printf("Skipped %-10s %s (row %d, already created)\n", key, rec.Summary, rec.Row)
+				skipped++
+				continue
+			}
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				key, err := createImportedIssue(client, job, byRef, keys, &mu)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					failed = append(failed, fmt.Sprintf("row %d (%s): %v", rec.Row, rec.Ref, err))
+					return
+				}
+				keys[rec.Ref] = key
+				created++
+				fmt.Printf("Created %-10s %s (row %d)\n", key, rec.Summary, rec.Row)
+				if err := writer.Write(importer.Result{Ref: rec.Ref, Key: key, Row: rec.Row, Summary: rec.Summary}); err != nil {
+					failed = append(failed, fmt.Sprintf("row %d (%s): created %s but %v", rec.Row, rec.Ref, key, err))
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	fmt.Printf("\n%d created, %d skipped, %d failed\n", created, skipped, len(failed))
+	fmt.Printf("Results: %s\n", resultsPath)
+	if len(failed) > 0 {
+		for _, f := range failed {
+			fmt.Println(f)
+		}
+		return fmt.Errorf("%d issue(s) failed, re-run the same command to retry", len(failed))
+	}
+	return nil
+}
+
+// validateImportRecord checks a record against the project's create metadata and
+// returns its fields resolved to IDs
+func validateImportRecord(client *jira.Client, rec importer.Record) (map[string]interface{}, []error) {
+	var errs []error
+	if rec.Summary == "" {
+		errs = append(errs, fmt.Errorf("summary is required"))
+	}
+	if rec.IssueType == "" {
+		errs = append(errs, fmt.Errorf("issue type is required"))
+		return nil, errs
+	}
+
+	fields, err := client.ResolveFieldValues(rec.Fields)
+	if err != nil {
+		return nil, append(errs, err)
+	}
+
+	metas, err := client.CreateMeta(rec.Project, rec.IssueType)
+	if err != nil {
+		return nil, append(errs, err)
+	}
+
+	available := make(map[string]bool, len(metas))
+	for _, meta := range metas {
+		available[meta.FieldID] = true
+		if !meta.Required || meta.HasDefaultValue {
+			continue
+		}
+
+		var present bool
+		switch {
+		case meta.FieldID == "summary":
+			present = rec.Summary != ""
+		case meta.FieldID == "description":
+			present = rec.Description != ""
+		case formSkipFields[meta.FieldID]:
+			present = true
+		case meta.FieldID == "labels":
+			present = len(rec.Labels) > 0
+		case meta.FieldID == "components":
+			present = len(rec.Components) > 0
+		case meta.IsEpicLink():
+			present = rec.Parent != ""
+		default:
+			_, present = fields[meta.FieldID]
+		}
+		if !present {
+			errs = append(errs, fmt.Errorf("missing required field %s", meta.Name))
+		}
+	}
+
+	for id := range fields {
+		if !available[id] {
+			errs = append(errs, fmt.Errorf("field %s cannot be set on %s issues in %s", id, rec.IssueType, rec.Project))
+		}
+	}
+
+	return fields, errs
+}
+
+// createImportedIssue creates one record, linking it to its parent as an epic child or sub-task
+func createImportedIssue(client *jira.Client, job importJob, byRef map[string]importer.Record, keys map[string]string, mu *sync.Mutex) (string, error) {
+	rec := job.record
+	opts := &jira.CreateIssueOptions{
+		Labels:     rec.Labels,
+		Components: rec.Components,
+		Fields:     job.fields,
+	}
+
+	if rec.Parent != "" {
+		parentKey := rec.Parent
+		parentType := ""
+		if parent, ok := byRef[rec.Parent]; ok {
+			mu.Lock()
+			parentKey = keys[rec.Parent]
+			mu.Unlock()
+			if parentKey == "" {
+				return "", fmt.Errorf("parent %s was not created", rec.Parent)
+			}
+			parentType = parent.IssueType
+		} else {
+			parent, err := client.GetIssue(parentKey)
+			if err != nil {
+				return "", err
+			}
+			if parent.Fields != nil {
+				parentType = parent.Fields.Type.Name
+			}
+		}
+
+		if strings.EqualFold(parentType, "Epic") {
+			opts.EpicLink = parentKey
+		} else {
+			opts.Parent = parentKey
+		}
+	}
+
+	issue, err := client.CreateIssue(rec.Project, rec.IssueType, rec.Summary, rec.Description, opts)
+	if err != nil {
+		return "", err
+	}
+	return issue.Key, nil
+}
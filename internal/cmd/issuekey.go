@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/git"
+)
+
+// issueKeyArg returns the issue key given on the command line, or the one in the
+// current git branch name when none is given
+func issueKeyArg(cfg *config.Config, args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.ToUpper(args[0]), nil
+	}
+
+	key, err := git.BranchIssueKey(cfg.Git.KeyPattern)
+	if err != nil {
+		return "", fmt.Errorf("no issue key given and none found in the current git branch: %w", err)
+	}
+	key = strings.ToUpper(key)
+	fmt.Printf("Using %s from the current git branch\n", key)
+	return key, nil
+}
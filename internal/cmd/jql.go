@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	runewidth "github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+var jqlCmd = &cobra.Command{
+	Use:   "jql",
+	Short: "Work with JQL",
+}
+
+var jqlValidateCmd = &cobra.Command{
+	Use:   "validate <jql|query>",
+	Short: "Check JQL for errors",
+	Long: `Check JQL with Jira's parser and show where any errors are.
+
+The argument is either a saved query name or a JQL string. Variables and
+parameters are expanded first, as when running a query.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runJQLValidate,
+}
+
+var jqlParams []string
+
+func init() {
+	RootCmd.AddCommand(jqlCmd)
+	jqlCmd.AddCommand(jqlValidateCmd)
+	jqlValidateCmd.Flags().StringArrayVarP(&jqlParams, "param", "p", nil, `Set a query parameter, e.g. -p label=infra (repeatable)`)
+}
+
+func runJQLValidate(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	flagParams, err := parseKeyValueFlags(jqlParams)
+	if err != nil {
+		return err
+	}
+
+	jql := args[0]
+	if query := cfg.GetQuery(args[0]); query != nil {
+		jql = query.JQL
+	}
+
+	params, err := resolveQueryParams(cfg, jql, flagParams)
+	if err != nil {
+		if err == ErrPromptCancelled {
+			fmt.Println("\nCancelled.")
+			return nil
+		}
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	jql, err = client.ExpandFieldNames(cfg.ExpandJQL(jql, params))
+	if err != nil {
+		return fmt.Errorf("failed to expand query: %w", err)
+	}
+
+	errs, err := client.ValidateJQL(jql)
+	if err != nil {
+		return err
+	}
+	if len(errs) == 0 {
+		fmt.Println("JQL is valid.")
+		return nil
+	}
+
+	printJQLErrors(jql, errs)
+	return fmt.Errorf("invalid JQL")
+}
+
+// printJQLErrors prints each error, with a marker under the failing position when Jira reports one
+func printJQLErrors(jql string, errs []jira.JQLError) {
+	lines := strings.Split(jql, "\n")
+	for _, e := range errs {
+		if e.Line >= 1 && e.Line <= len(lines) && e.Column >= 1 {
+			line := lines[e.Line-1]
+			runes := []rune(line)
+			col := min(e.Column-1, len(runes))
+			fmt.Printf("  %s\n", line)
+			fmt.Printf("  %s^\n", strings.Repeat(" ", runewidth.StringWidth(string(runes[:col]))))
+		}
+		fmt.Printf("Error: %s\n", e.Message)
+	}
+}
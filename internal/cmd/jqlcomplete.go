@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+// jqlOperators are offered when Jira does not list the operators for a field
+var jqlOperators = []string{"=", "!=", "~", "!~", ">", ">=", "<", "<=", "IN", "NOT IN", "IS", "IS NOT", "WAS", "WAS NOT", "CHANGED"}
+
+// jqlState is what the completer expects next in a JQL query
+type jqlState int
+
+const (
+	jqlExpectField jqlState = iota
+	jqlExpectOperator
+	jqlExpectValue
+	jqlExpectListValue
+	jqlExpectKeyword
+	jqlExpectBy
+	jqlExpectOrderField
+	jqlExpectDirection
+)
+
+// jqlCompleter is a readline completer for JQL. Field, operator and function names come from
+// Jira's autocomplete data (cached per server); values are fetched as suggestions while typing.
+type jqlCompleter struct {
+	client      *jira.Client
+	data        *jira.JQLAutocompleteData
+	loaded      bool
+	suggestions map[string][]jira.JQLSuggestion
+}
+
+func newJQLCompleter(client *jira.Client) *jqlCompleter {
+	return &jqlCompleter{client: client, suggestions: make(map[string][]jira.JQLSuggestion)}
+}
+
+// promptJQL prompts for JQL with tab completion
+func promptJQL(client *jira.Client, label, defaultVal string) (string, error) {
+	return promptTextWithCompleter(label, defaultVal, true, newJQLCompleter(client))
+}
+
+// Do implements readline.AutoCompleter
+func (c *jqlCompleter) Do(line []rune, pos int) ([][]rune, int) {
+	tokens, current := jqlTokens(string(line[:pos]))
+	state, field := jqlParseState(tokens)
+
+	var candidates []string
+	switch state {
+	case jqlExpectField:
+		candidates = append(c.fieldNames(false), "NOT")
+	case jqlExpectOperator:
+		candidates = c.operators(field)
+	case jqlExpectValue, jqlExpectListValue:
+		candidates = append(c.values(field, current), c.functionNames()...)
+		if state == jqlExpectValue {
+			candidates = append(candidates, "EMPTY", "NULL")
+		}
+	case jqlExpectKeyword:
+		candidates = []string{"AND", "OR", "ORDER BY"}
+	case jqlExpectBy:
+		candidates = []string{"BY"}
+	case jqlExpectOrderField:
+		candidates = c.fieldNames(true)
+	case jqlExpectDirection:
+		candidates = []string{"ASC", "DESC"}
+	}
+
+	prefix := []rune(current)
+	lower := strings.ToLower(current)
+	seen := make(map[string]bool)
+	var out [][]rune
+	for _, cand := range candidates {
+		if seen[cand] || !strings.HasPrefix(strings.ToLower(cand), lower) {
+			continue
+		}
+		seen[cand] = true
+		rest := []rune(cand)[len(prefix):]
+		out = append(out, append(rest, ' '))
+	}
+	return out, len(prefix)
+}
+
+// load fetches autocomplete data once; failures leave the completer offering keywords only
+func (c *jqlCompleter) load() *jira.JQLAutocompleteData {
+	if !c.loaded {
+		c.loaded = true
+		c.data, _ = c.client.JQLAutocomplete()
+	}
+	return c.data
+}
+
+func (c *jqlCompleter) fieldNames(orderable bool) []string {
+	data := c.load()
+	if data == nil {
+		return nil
+	}
+
+	var names []string
+	for _, f := range data.Fields {
+		if orderable && f.Orderable != "true" {
+			continue
+		}
+		if !orderable && f.Searchable == "false" {
+			continue
+		}
+		names = append(names, quoteJQL(f.Value))
+	}
+	return names
+}
+
+func (c *jqlCompleter) operators(field string) []string {
+	if f := c.findField(field); f != nil && len(f.Operators) > 0 {
+		ops := make([]string, len(f.Operators))
+		for i, op := range f.Operators {
+			ops[i] = strings.ToUpper(op)
+		}
+		return ops
+	}
+	return jqlOperators
+}
+
+func (c *jqlCompleter) functionNames() []string {
+	data := c.load()
+	if data == nil {
+		return nil
+	}
+
+	names := make([]string, len(data.Functions))
+	for i, f := range data.Functions {
+		names[i] = f.Value
+	}
+	return names
+}
+
+func (c *jqlCompleter) values(field, prefix string) []string {
+	name := unquoteJQL(field)
+	if f := c.findField(field); f != nil && f.CfID != "" {
+		name = f.CfID
+	}
+	prefix = unquoteJQL(prefix)
+
+	key := name + "\x00" + prefix
+	suggestions, ok := c.suggestions[key]
+	if !ok {
+		suggestions, _ = c.client.JQLSuggestions(name, prefix)
+		c.suggestions[key] = suggestions
+	}
+
+	values := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		values[i] = quoteJQL(s.Value)
+	}
+	return values
+}
+
+// findField looks up a field by its JQL name, ignoring case and quotes
+func (c *jqlCompleter) findField(name string) *jira.JQLField {
+	data := c.load()
+	if data == nil {
+		return nil
+	}
+
+	name = unquoteJQL(name)
+	for i, f := range data.Fields {
+		if strings.EqualFold(unquoteJQL(f.Value), name) || strings.EqualFold(f.CfID, name) {
+			return &data.Fields[i]
+		}
+	}
+	return nil
+}
+
+// jqlTokens splits JQL into complete tokens and the partial token being typed.
+// Quoted strings are single tokens; parentheses, commas and operators stand alone.
+func jqlTokens(s string) (tokens []string, current string) {
+	var buf strings.Builder
+	var quote rune
+	flush := func() {
+		if buf.Len() > 0 {
+			tokens = append(tokens, buf.String())
+			buf.Reset()
+		}
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			buf.WriteRune(r)
+			if r == '\\' && i+1 < len(runes) {
+				i++
+				buf.WriteRune(runes[i])
+			} else if r == quote {
+				quote = 0
+				flush()
+			}
+		case r == '"' || r == '\'':
+			flush()
+			quote = r
+			buf.WriteRune(r)
+		case unicode.IsSpace(r):
+			flush()
+		case r == '(' || r == ')' || r == ',':
+			// Function calls such as currentUser() stay a single token
+			if r == '(' && buf.Len() > 0 && i+1 < len(runes) && runes[i+1] == ')' {
+				buf.WriteString("()")
+				i++
+				continue
+			}
+			flush()
+			tokens = append(tokens, string(r))
+		case strings.ContainsRune("=!<>~", r):
+			flush()
+			op := string(r)
+			for i+1 < len(runes) && strings.ContainsRune("=~", runes[i+1]) {
+				i++
+				op += string(runes[i])
+			}
+			tokens = append(tokens, op)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	return tokens, buf.String()
+}
+
+// jqlParseState walks the tokens and returns what comes next, along with the
+// field the current clause is about
+func jqlParseState(tokens []string) (jqlState, string) {
+	state := jqlExpectField
+	field := ""
+
+	for _, tok := range tokens {
+		up := strings.ToUpper(tok)
+		switch state {
+		case jqlExpectField:
+			switch up {
+			case "AND", "OR", "NOT", "(":
+			case "ORDER":
+				state = jqlExpectBy
+			default:
+				field = tok
+				state = jqlExpectOperator
+			}
+		case jqlExpectOperator:
+			switch up {
+			case "CHANGED":
+				state = jqlExpectKeyword
+			default:
+				state = jqlExpectValue
+			}
+		case jqlExpectValue:
+			switch up {
+			case "NOT", "IN":
+			case "(":
+				state = jqlExpectListValue
+			default:
+				state = jqlExpectKeyword
+			}
+		case jqlExpectListValue:
+			if up == ")" {
+				state = jqlExpectKeyword
+			}
+		case jqlExpectKeyword:
+			switch up {
+			case "AND", "OR":
+				state = jqlExpectField
+			case "ORDER":
+				state = jqlExpectBy
+			}
+		case jqlExpectBy:
+			if up == "BY" {
+				state = jqlExpectOrderField
+			}
+		case jqlExpectOrderField:
+			if up != "," {
+				state = jqlExpectDirection
+			}
+		case jqlExpectDirection:
+			if up == "," {
+				state = jqlExpectOrderField
+			}
+		}
+	}
+	return state, field
+}
+
+// quoteJQL quotes a name or value if JQL requires it
+func quoteJQL(s string) string {
+	if s == "" || strings.HasPrefix(s, `"`) || strings.HasSuffix(s, "()") {
+		return s
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_.-@[]", r) {
+			return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+		}
+	}
+	return s
+}
+
+// unquoteJQL strips the quotes around a JQL name or value, including an unterminated one
+func unquoteJQL(s string) string {
+	if len(s) > 0 && (s[0] == '"' || s[0] == '\'') {
+		s = strings.TrimSuffix(s[1:], s[:1])
+	}
+	return strings.ReplaceAll(s, `\"`, `"`)
+}
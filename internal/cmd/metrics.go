@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/metrics"
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+	"github.com/spf13/cobra"
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics [query|jql]",
+	Short: "Report velocity, lead time, cycle time and throughput",
+	Long: `Report delivery metrics for a board or for the issues matching a saved query or JQL.
+
+Lead time runs from an issue's creation to done, cycle time from the first move into
+an in-progress status to done; both are read from the status history of issues finished
+in the last --weeks. Which statuses count as in progress and done can be set in the
+[metrics] section of the config file; they default to Jira's status categories.
+Velocity (committed and completed work in the last --sprints closed sprints) is shown
+for scrum boards.
+
+  jiractl metrics                              # Configured board
+  jiractl metrics -b "PROJ board" --histogram
+  jiractl metrics "project = PROJ AND type = Bug" --weeks 26
+  jiractl metrics --format csv --data throughput > throughput.csv`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMetrics,
+}
+
+var (
+	metricsBoard     string
+	metricsSprints   int
+	metricsWeeks     int
+	metricsFormat    string
+	metricsData      string
+	metricsHistogram bool
+	metricsIssues    bool
+)
+
+func init() {
+	RootCmd.AddCommand(metricsCmd)
+	metricsCmd.Flags().StringVarP(&metricsBoard, "board", "b", "", "Board ID or name")
+	metricsCmd.Flags().IntVar(&metricsSprints, "sprints", 6, "Number of closed sprints for velocity")
+	metricsCmd.Flags().IntVar(&metricsWeeks, "weeks", 12, "Include issues finished in this many weeks")
+	metricsCmd.Flags().StringVar(&metricsFormat, "format", "table", "Output format: table or csv")
+	metricsCmd.Flags().StringVar(&metricsData, "data", metrics.DataIssues, "Table to write as CSV: issues, throughput or velocity")
+	metricsCmd.Flags().BoolVar(&metricsHistogram, "histogram", false, "Show lead and cycle time histograms")
+	metricsCmd.Flags().BoolVar(&metricsIssues, "issues", false, "List the lead and cycle time of each issue")
+}
+
+func runMetrics(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if metricsFormat != "table" && metricsFormat != "csv" {
+		return fmt.Errorf("unsupported format %q (use table or csv)", metricsFormat)
+	}
+	if metricsWeeks <= 0 {
+		return fmt.Errorf("--weeks must be positive")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Velocity needs a board: the one given, or the default board when no query is given
+	var board *jira.Board
+	if len(args) == 0 || metricsBoard != "" {
+		board, err = resolveBoard(cfg, client, metricsBoard)
+		if err != nil {
+			if err == fuzzyfinder.ErrAbort {
+				return nil
+			}
+			return err
+		}
+	}
+
+	var jql string
+	if len(args) > 0 {
+		query := cfg.GetQuery(args[0])
+		if query == nil {
+			query = &config.Query{Name: "jql", JQL: args[0]}
+		}
+		jql, err = expandQuery(cfg, client, query, nil)
+		if err != nil {
+			if err == ErrPromptCancelled {
+				fmt.Println("\nCancelled.")
+				return nil
+			}
+			return err
+		}
+	} else {
+		boardConfig, err := client.GetBoardConfiguration(board.ID)
+		if err != nil {
+			return err
+		}
+		filter, err := client.GetFilter(boardConfig.Filter.ID)
+		if err != nil {
+			return err
+		}
+		jql = filter.Jql
+	}
+
+	stages, err := metricsStages(cfg, client)
+	if err != nil {
+		return err
+	}
+
+	until := time.Now()
+	since := until.AddDate(0, 0, -7*metricsWeeks)
+	// Anything finished since then has been updated since then
+	jql = fmt.Sprintf(`(%s) AND updated >= "%s"`, orderByPattern.ReplaceAllString(jql, ""), since.Format("2006-01-02"))
+	issues, err := searchAllIssues(client, jql, jira.SearchOptions{Fields: metrics.Fields, Expand: []string{"changelog"}})
+	if err != nil {
+		return err
+	}
+
+	times := make([]metrics.IssueTimes, len(issues))
+	for i, issue := range issues {
+		times[i] = metrics.Times(issue, stages)
+	}
+	result := metrics.NewResult(times, since, until)
+
+	if board != nil && board.Type == "scrum" && metricsSprints > 0 {
+		if err := addVelocity(client, board, result, metricsSprints); err != nil {
+			return err
+		}
+	}
+
+	if metricsFormat == "csv" {
+		return metrics.WriteCSV(os.Stdout, result, metricsData)
+	}
+	return metrics.WriteTable(os.Stdout, result, metrics.TableOptions{Histogram: metricsHistogram, Issues: metricsIssues})
+}
+
+// metricsStages maps statuses to stages using the config, falling back to status categories
+func metricsStages(cfg *config.Config, client *jira.Client) (metrics.Stages, error) {
+	statuses, err := client.GetStatuses()
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[string]string, len(statuses))
+	for _, s := range statuses {
+		categories[s.Name] = s.StatusCategory.Key
+	}
+	return metrics.NewStages(categories, cfg.Metrics.InProgress, cfg.Metrics.Done), nil
+}
+
+// addVelocity adds the committed and completed work of the board's last n closed sprints
+func addVelocity(client *jira.Client, board *jira.Board, result *metrics.Result, n int) error {
+	sprints, err := client.GetSprints(board.ID, jira.SprintClosed)
+	if err != nil {
+		return err
+	}
+	sort.Slice(sprints, func(i, j int) bool {
+		return sprintCompleted(sprints[i]).Before(sprintCompleted(sprints[j]))
+	})
+	if len(sprints) > n {
+		sprints = sprints[len(sprints)-n:]
+	}
+	if len(sprints) == 0 {
+		return nil
+	}
+
+	reporter, err := newSprintReporter(client, board)
+	if err != nil {
+		return err
+	}
+	for i := range sprints {
+		// Issues removed during the sprint still count as committed
+		r, err := reporter.build(&sprints[i], true)
+		if err != nil {
+			return err
+		}
+		v := metrics.SprintVelocity{Sprint: sprints[i].Name, Committed: r.Committed.Points, Completed: r.Completed.Points}
+		if r.Unit == "issues" {
+			v.Committed, v.Completed = float64(r.Committed.Issues), float64(r.Completed.Issues)
+		}
+		result.Unit = r.Unit
+		result.Velocity = append(result.Velocity, v)
+	}
+	return nil
+}
+
+// sprintCompleted returns when a closed sprint was completed, or its end date
+func sprintCompleted(s jira.Sprint) time.Time {
+	switch {
+	case s.CompleteDate != nil:
+		return *s.CompleteDate
+	case s.EndDate != nil:
+		return *s.EndDate
+	}
+	return time.Time{}
+}
@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/cache"
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/notify"
+	"github.com/eugenetaranov/jiractl/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultNotifyInterval  = 5 * time.Minute
+	defaultNotifyRateLimit = 15 * time.Minute
+	// notifyDedupWindow is how long an issue is remembered after a notification, so
+	// issues that drop out of a query and come back are not announced again
+	notifyDedupWindow = 24 * time.Hour
+	// notifyMaxListed caps the issues listed in a single notification
+	notifyMaxListed = 5
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify [query...]",
+	Short: "Send desktop notifications for new issues in saved queries",
+	Long: `Check saved queries and send a notification when new issues appear.
+
+Each query's results are kept in the state directory, so notify can run from cron
+as well as in the foreground with --daemon. The first check of a query only records
+its current issues. Notifications for a query are sent at most once per rate limit
+(later issues are batched), and an issue is not announced again within 24 hours.
+
+Notifications go to the desktop over D-Bus, or to a command set with --command or
+notify.command in the config file.`,
+	RunE: runNotify,
+}
+
+var (
+	notifyDaemon    bool
+	notifyInterval  time.Duration
+	notifyRateLimit time.Duration
+	notifyCommand   string
+	notifyTest      bool
+)
+
+func init() {
+	RootCmd.AddCommand(notifyCmd)
+	notifyCmd.Flags().BoolVar(&notifyDaemon, "daemon", false, "Keep running, checking every --interval")
+	notifyCmd.Flags().DurationVar(&notifyInterval, "interval", 0, "Time between checks in daemon mode (default 5m)")
+	notifyCmd.Flags().DurationVar(&notifyRateLimit, "rate-limit", 0, "Minimum time between notifications for a query (default 15m)")
+	notifyCmd.Flags().StringVar(&notifyCommand, "command", "", "Command to run for each notification instead of using D-Bus")
+	notifyCmd.Flags().BoolVar(&notifyTest, "test", false, "Send a test notification and exit")
+}
+
+// notifyState is kept per query between runs
+type notifyState struct {
+	Snapshot watch.Snapshot `json:"snapshot"`
+	// Pending are new issues held back by the rate limit
+	Pending []watch.IssueState `json:"pending,omitempty"`
+	// Notified maps issue keys to when they were last announced
+	Notified map[string]time.Time `json:"notified,omitempty"`
+	LastSent time.Time            `json:"last_sent"`
+}
+
+// notifyOptions are the resolved settings for a notify run
+type notifyOptions struct {
+	interval  time.Duration
+	rateLimit time.Duration
+	command   string
+}
+
+func runNotify(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	opts, err := resolveNotifyOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	if notifyTest {
+		n := notify.Notification{Title: "jiractl", Body: "Test notification"}
+		if err := notify.Send(n, opts.command); err != nil {
+			return err
+		}
+		fmt.Println("Notification sent.")
+		return nil
+	}
+
+	names := args
+	if len(names) == 0 {
+		names = cfg.Notify.Queries
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no queries to check: pass query names or set queries in [notify]")
+	}
+
+	queries := make([]*config.Query, len(names))
+	for i, name := range names {
+		if queries[i], err = findQuery(cfg, name); err != nil {
+			return err
+		}
+		// Notify runs unattended, so parameters cannot be prompted for
+		for _, p := range cfg.QueryParams(queries[i].JQL) {
+			if _, ok := os.LookupEnv("JIRACTL_PARAM_" + strings.ToUpper(p.Name)); !ok && !p.HasDefault {
+				return fmt.Errorf("query %s needs a value for ${%s}; set JIRACTL_PARAM_%s", name, p.Name, strings.ToUpper(p.Name))
+			}
+		}
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	if !notifyDaemon {
+		return checkQueries(cfg, client, queries, opts)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Checking %d queries every %s (Ctrl+C to stop)\n", len(queries), opts.interval)
+	for {
+		if err := checkQueries(cfg, client, queries, opts); err != nil {
+			fmt.Printf("%s  %v\n", time.Now().Format("15:04:05"), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.interval):
+		}
+	}
+}
+
+// resolveNotifyOptions combines flags with the [notify] config section
+func resolveNotifyOptions(cfg *config.Config) (notifyOptions, error) {
+	opts := notifyOptions{interval: notifyInterval, rateLimit: notifyRateLimit, command: notifyCommand}
+
+	var err error
+	if opts.interval == 0 {
+		if opts.interval, err = parseDurationSetting("interval", cfg.Notify.Interval, defaultNotifyInterval); err != nil {
+			return opts, err
+		}
+	}
+	if opts.rateLimit == 0 {
+		if opts.rateLimit, err = parseDurationSetting("rate_limit", cfg.Notify.RateLimit, defaultNotifyRateLimit); err != nil {
+			return opts, err
+		}
+	}
+	if opts.command == "" {
+		opts.command = cfg.Notify.Command
+	}
+
+	if opts.interval < minWatchInterval {
+		return opts, fmt.Errorf("interval must be at least %s", minWatchInterval)
+	}
+	return opts, nil
+}
+
+// parseDurationSetting parses a duration from the config file, returning def if it is unset
+func parseDurationSetting(name, value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid notify %s %q: %w", name, value, err)
+	}
+	return d, nil
+}
+
+// checkQueries checks every query, reporting failures without stopping at the first one
+func checkQueries(cfg *config.Config, client *jira.Client, queries []*config.Query, opts notifyOptions) error {
+	failed := 0
+	for _, q := range queries {
+		if err := checkQuery(cfg, client, q, opts); err != nil {
+			fmt.Printf("%s: %v\n", q.Name, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d queries failed", failed, len(queries))
+	}
+	return nil
+}
+
+// checkQuery compares a query's results with the previous run and sends a notification
+// for new issues, subject to deduplication and the rate limit
+func checkQuery(cfg *config.Config, client *jira.Client, query *config.Query, opts notifyOptions) error {
+	jql, err := expandQuery(cfg, client, query, nil)
+	if err != nil {
+		return err
+	}
+
+	cur, err := fetchSnapshot(client, jql, query.Limit)
+	if err != nil {
+		return err
+	}
+
+	stateName := "notify_" + query.Name
+	var state notifyState
+	found, err := cache.LoadState(cfg.Server, stateName, &state)
+	if err != nil {
+		return err
+	}
+	if !found {
+		state.Snapshot = cur
+		fmt.Printf("%s: recorded %d issues\n", query.Name, len(cur))
+		return cache.SaveState(cfg.Server, stateName, state)
+	}
+
+	now := time.Now()
+	if state.Notified == nil {
+		state.Notified = make(map[string]time.Time)
+	}
+	for key, at := range state.Notified {
+		if now.Sub(at) > notifyDedupWindow {
+			delete(state.Notified, key)
+		}
+	}
+
+	// Keep pending issues that are still in the query, then add the newly entered ones
+	var pending []watch.IssueState
+	queued := make(map[string]bool)
+	for _, issue := range state.Pending {
+		if current, ok := cur[issue.Key]; ok && !queued[issue.Key] {
+			pending = append(pending, current)
+			queued[issue.Key] = true
+		}
+	}
+	for _, change := range watch.Diff(state.Snapshot, cur) {
+		key := change.Issue.Key
+		if change.Type != watch.Entered || queued[key] {
+			continue
+		}
+		if _, seen := state.Notified[key]; seen {
+			continue
+		}
+		pending = append(pending, change.Issue)
+		queued[key] = true
+	}
+	state.Pending = pending
+	state.Snapshot = cur
+
+	if len(pending) > 0 && now.Sub(state.LastSent) >= opts.rateLimit {
+		n := buildNotification(cfg.Server, query.Name, pending)
+		if err := notify.Send(n, opts.command); err != nil {
+			// Keep the issues pending so they are announced on the next run
+			_ = cache.SaveState(cfg.Server, stateName, state)
+			return err
+		}
+		fmt.Printf("%s: %s\n", query.Name, n.Title)
+		for _, issue := range pending {
+			state.Notified[issue.Key] = now
+		}
+		state.Pending = nil
+		state.LastSent = now
+	} else if len(pending) > 0 {
+		fmt.Printf("%s: %d new issues held back by the rate limit\n", query.Name, len(pending))
+	}
+
+	return cache.SaveState(cfg.Server, stateName, state)
+}
+
+// buildNotification describes new issues, linking directly to the issue when there is only one
+func buildNotification(server, queryName string, issues []watch.IssueState) notify.Notification {
+	n := notify.Notification{Query: queryName}
+	for _, issue := range issues {
+		n.Keys = append(n.Keys, issue.Key)
+	}
+
+	if len(issues) == 1 {
+		n.Title = fmt.Sprintf("%s: %s", queryName, issues[0].Key)
+		n.Body = issues[0].Summary
+		n.URL = issueURL(server, issues[0].Key)
+		return n
+	}
+
+	n.Title = fmt.Sprintf("%s: %d new issues", queryName, len(issues))
+	lines := make([]string, 0, notifyMaxListed+1)
+	for i, issue := range issues {
+		if i == notifyMaxListed {
+			lines = append(lines, fmt.Sprintf("...and %d more", len(issues)-notifyMaxListed))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", issue.Key, issue.Summary))
+	}
+	n.Body = strings.Join(lines, "\n")
+	return n
+}
@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var queryAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a saved query",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runQueryAdd,
+}
+
+var queryEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Edit a saved query in $EDITOR",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runQueryEdit,
+}
+
+var queryRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove", "delete"},
+	Short:   "Remove a saved query",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runQueryRm,
+}
+
+var queryRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a saved query",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runQueryRename,
+}
+
+var queryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved queries",
+	Args:  cobra.NoArgs,
+	RunE:  runQueryList,
+}
+
+var queryShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a saved query",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runQueryShow,
+}
+
+var (
+	queryAddJQL     string
+	queryAddLimit   int
+	queryAddColumns []string
+	queryAddFields  []string
+	queryNoValidate bool
+)
+
+func init() {
+	queryCmd.AddCommand(queryAddCmd)
+	queryCmd.AddCommand(queryEditCmd)
+	queryCmd.AddCommand(queryRmCmd)
+	queryCmd.AddCommand(queryRenameCmd)
+	queryCmd.AddCommand(queryListCmd)
+	queryCmd.AddCommand(queryShowCmd)
+
+	queryAddCmd.Flags().StringVar(&queryAddJQL, "jql", "", "JQL for the query (prompted for if omitted)")
+	queryAddCmd.Flags().IntVar(&queryAddLimit, "limit", 0, "Maximum number of results")
+	queryAddCmd.Flags().StringSliceVar(&queryAddColumns, "columns", nil, "Columns to show in the results picker")
+	queryAddCmd.Flags().StringSliceVar(&queryAddFields, "fields", nil, "Extra fields to fetch")
+	queryAddCmd.Flags().BoolVar(&queryNoValidate, "no-validate", false, "Save without checking the JQL against the server")
+	queryEditCmd.Flags().BoolVar(&queryNoValidate, "no-validate", false, "Save without checking the JQL against the server")
+}
+
+func runQueryAdd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := config.CheckQueryName(args[0]); err != nil {
+		return err
+	}
+	if cfg.GetQuery(args[0]) != nil {
+		return fmt.Errorf("query already exists: %s", args[0])
+	}
+
+	jql := queryAddJQL
+	if jql == "" {
+		// Completion needs a client; without credentials fall back to a plain prompt
+		var completer readline.AutoCompleter
+		if client, err := jira.NewClient(cfg); err == nil {
+			completer = newJQLCompleter(client)
+		}
+		jql, err = promptTextWithCompleter("JQL", "", true, completer)
+		if err != nil {
+			if err == ErrPromptCancelled {
+				fmt.Println("\nCancelled.")
+				return nil
+			}
+			return err
+		}
+	}
+
+	query := config.Query{
+		Name:    args[0],
+		JQL:     jql,
+		Limit:   queryAddLimit,
+		Columns: queryAddColumns,
+		Fields:  queryAddFields,
+	}
+
+	if !queryNoValidate {
+		if err := validateSavedQuery(cfg, &query); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.AddQuery(query); err != nil {
+		return fmt.Errorf("failed to save query: %w", err)
+	}
+
+	fmt.Printf("Query saved: %s\n", query.Name)
+	return nil
+}
+
+func runQueryEdit(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	query := cfg.GetQuery(args[0])
+	if query == nil {
+		return fmt.Errorf("query not found: %s", args[0])
+	}
+
+	content, err := config.RenderQuery(*query)
+	if err != nil {
+		return err
+	}
+
+	// Re-open the editor on invalid input rather than losing the user's changes
+	for {
+		content, err = editInEditor(content, "jiractl-query-*.toml")
+		if err != nil {
+			return err
+		}
+
+		edited, err := config.ParseQuery(content)
+		if err == nil && edited.Name == "" {
+			err = fmt.Errorf("query name is required")
+		}
+		if err == nil && edited.JQL == "" {
+			err = fmt.Errorf("query jql is required")
+		}
+		if err == nil && !queryNoValidate {
+			err = validateSavedQuery(cfg, edited)
+		}
+		if err == nil {
+			if err := cfg.UpdateQuery(args[0], *edited); err != nil {
+				return fmt.Errorf("failed to save query: %w", err)
+			}
+			fmt.Printf("Query saved: %s\n", edited.Name)
+			return nil
+		}
+
+		fmt.Printf("Error: %v\n", err)
+		retry, perr := promptConfirm("Edit again?")
+		if perr != nil {
+			return perr
+		}
+		if !retry {
+			fmt.Println("Changes discarded.")
+			return nil
+		}
+	}
+}
+
+func runQueryRm(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.GetQuery(args[0]) == nil {
+		return fmt.Errorf("query not found: %s", args[0])
+	}
+
+	confirmed, err := promptConfirm(fmt.Sprintf("Remove query %q?", args[0]))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	if err := cfg.RemoveQuery(args[0]); err != nil {
+		return fmt.Errorf("failed to remove query: %w", err)
+	}
+
+	fmt.Printf("Query removed: %s\n", args[0])
+	return nil
+}
+
+func runQueryRename(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.RenameQuery(args[0], args[1]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Query renamed: %s -> %s\n", args[0], args[1])
+	return nil
+}
+
+func runQueryList(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.Queries) == 0 {
+		fmt.Println("No queries configured.")
+		return nil
+	}
+
+	width := 0
+	for _, q := range cfg.Queries {
+		width = max(width, len(q.Name))
+	}
+	for _, q := range cfg.Queries {
+		fmt.Printf("%-*s  %s\n", width, q.Name, q.JQL)
+	}
+	return nil
+}
+
+func runQueryShow(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	query := cfg.GetQuery(args[0])
+	if query == nil {
+		return fmt.Errorf("query not found: %s", args[0])
+	}
+
+	content, err := config.RenderQuery(*query)
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+
+	if params := cfg.QueryParams(query.JQL); len(params) > 0 {
+		fmt.Println("\n# Parameters:")
+		for _, p := range params {
+			if p.HasDefault {
+				fmt.Printf("#   %s (default %q)\n", p.Name, p.Default)
+			} else {
+				fmt.Printf("#   %s\n", p.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSavedQuery checks that the query's JQL is accepted by the server. Parameters
+// are filled with their defaults; queries with required parameters are only checked
+// once values are available, so they are skipped here.
+func validateSavedQuery(cfg *config.Config, query *config.Query) error {
+	for _, p := range cfg.QueryParams(query.JQL) {
+		if !p.HasDefault {
+			fmt.Printf("Skipping validation: query needs a value for ${%s}\n", p.Name)
+			return nil
+		}
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	jql, err := client.ExpandFieldNames(cfg.ExpandJQL(query.JQL, nil))
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("Validating JQL... ")
+	errs, err := client.ValidateJQL(jql)
+	if err != nil {
+		fmt.Println("failed")
+		return err
+	}
+	if len(errs) > 0 {
+		fmt.Println("failed")
+		printJQLErrors(jql, errs)
+		return fmt.Errorf("invalid JQL")
+	}
+	fmt.Println("ok")
+	return nil
+}
+
+// editInEditor opens content in $VISUAL or $EDITOR (falling back to vi) and returns the result
+func editInEditor(content, pattern string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// $EDITOR may include arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	c := exec.Command(parts[0], append(parts[1:], f.Name())...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("editor failed: %w", err)
+	}
+
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read temp file: %w", err)
+	}
+	return string(data), nil
+}
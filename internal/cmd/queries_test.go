@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/eugenetaranov/jiractl/internal/config"
+)
+
+// TestReservedQueryNames checks that every query subcommand is reserved, so no saved
+// query can be hidden by one
+func TestReservedQueryNames(t *testing.T) {
+	for _, sub := range queryCmd.Commands() {
+		for _, name := range append([]string{sub.Name()}, sub.Aliases...) {
+			if err := config.CheckQueryName(name); err == nil {
+				t.Errorf("query subcommand %q is missing from config.ReservedQueryNames", name)
+			}
+		}
+	}
+}
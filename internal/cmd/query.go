@@ -2,7 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/git"
 	"github.com/eugenetaranov/jiractl/internal/jira"
 	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
 	"github.com/spf13/cobra"
@@ -11,33 +16,114 @@ import (
 var queryCmd = &cobra.Command{
 	Use:   "query [name]",
 	Short: "Run a saved query",
-	Long:  `Run a saved JQL query from your config file and display results interactively.`,
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  runQueryCmd,
+	Long: `Run a saved JQL query from your config file and display results interactively.
+
+Queries may use ${name} or ${name:default} parameters. Values come from --param,
+JIRACTL_PARAM_<NAME> environment variables, the default, or are prompted for.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runQueryCmd,
 }
 
+var (
+	queryParams   []string
+	queryFilter   string
+	queryWatch    bool
+	queryInterval time.Duration
+	queryExec     string
+	querySince    bool
+	queryBranch   bool
+	queryStart    bool
+	queryAssign   bool
+
+	// queryRunCmd is the running query command, whose --start and --assign apply to
+	// branches created from its results. It is nil when queries run from the menu.
+	queryRunCmd *cobra.Command
+)
+
 func init() {
 	RootCmd.AddCommand(queryCmd)
+	queryCmd.Flags().StringArrayVarP(&queryParams, "param", "p", nil, `Set a query parameter, e.g. -p label=infra (repeatable)`)
+	queryCmd.Flags().StringVar(&queryFilter, "filter", "", "Run a server-side Jira filter by ID instead of a saved query")
+	queryCmd.Flags().BoolVarP(&queryWatch, "watch", "w", false, "Re-run the query periodically and report changes")
+	queryCmd.Flags().DurationVar(&queryInterval, "interval", 60*time.Second, "Time between polls in watch mode")
+	queryCmd.Flags().StringVar(&queryExec, "exec", "", "Command to run for each change in watch mode (issue data as JSON on stdin)")
+	queryCmd.Flags().BoolVar(&querySince, "since-last", false, "Show only issues that are new, removed or updated since the previous --since-last run")
+	queryCmd.Flags().BoolVar(&queryBranch, "branch", false, "Create a git branch for the selected issue instead of showing its details")
+	queryCmd.Flags().BoolVar(&queryStart, "start", false, "When creating a branch, move the issue to the start status")
+	queryCmd.Flags().BoolVar(&queryAssign, "assign", false, "When creating a branch, assign the issue to yourself")
 }
 
 func runQueryCmd(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
+	queryRunCmd = cmd
+
+	if queryFilter != "" && len(args) > 0 {
+		return fmt.Errorf("--filter cannot be combined with a query name")
+	}
+
+	if querySince && (queryFilter != "" || len(args) == 0) {
+		return fmt.Errorf("--since-last requires a saved query name")
+	}
+	if querySince && queryWatch {
+		return fmt.Errorf("--since-last cannot be combined with --watch")
+	}
+	if queryBranch && queryWatch {
+		return fmt.Errorf("--branch cannot be combined with --watch")
+	}
+	if queryBranch && !git.InWorkTree() {
+		return fmt.Errorf("--branch needs to be run inside a git repository")
+	}
+
+	params, err := parseKeyValueFlags(queryParams)
+	if err != nil {
+		return err
+	}
+
+	if queryFilter == "" && len(args) == 0 {
+		if queryWatch {
+			return fmt.Errorf("--watch requires a query name or --filter")
+		}
+		return runQueryInteractive(params)
+	}
 
-	if len(args) == 0 {
-		return runQueryInteractive()
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
 	}
-	return runQuery(args[0])
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	var query *config.Query
+	if queryFilter != "" {
+		query, err = filterQuery(client, queryFilter)
+	} else {
+		query, err = findQuery(cfg, args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	if queryWatch {
+		return watchQuery(cfg, client, query, params, queryInterval, queryExec)
+	}
+	if querySince {
+		return runSinceLast(cfg, client, query, params)
+	}
+	return runSavedQuery(cfg, client, query, params)
 }
 
-func runQuery(queryName string) error {
+func runQuery(queryName string, params map[string]string) error {
 	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
 
-	query := cfg.GetQuery(queryName)
-	if query == nil {
-		return fmt.Errorf("query not found: %s", queryName)
+	query, err := findQuery(cfg, queryName)
+	if err != nil {
+		return err
 	}
 
 	client, err := jira.NewClient(cfg)
@@ -45,44 +131,113 @@ func runQuery(queryName string) error {
 		return err
 	}
 
-	// Expand variables in JQL
-	jql := cfg.ExpandJQL(query.JQL)
+	return runSavedQuery(cfg, client, query, params)
+}
+
+// findQuery returns a saved query by name
+func findQuery(cfg *config.Config, name string) (*config.Query, error) {
+	query := cfg.GetQuery(name)
+	if query == nil {
+		return nil, fmt.Errorf("query not found: %s", name)
+	}
+	return query, nil
+}
+
+// filterQuery returns a server-side filter as an unsaved query
+func filterQuery(client *jira.Client, filterID string) (*config.Query, error) {
+	filter, err := client.GetFilter(filterID)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Query{Name: filter.Name, JQL: filter.Jql, FilterID: filter.ID}, nil
+}
+
+// expandQuery resolves a query's parameters and returns its JQL ready to send to Jira
+func expandQuery(cfg *config.Config, client *jira.Client, query *config.Query, params map[string]string) (string, error) {
+	params, err := resolveQueryParams(cfg, query.JQL, params)
+	if err != nil {
+		return "", err
+	}
+	jql, err := client.ExpandFieldNames(cfg.ExpandJQL(query.JQL, params))
+	if err != nil {
+		return "", fmt.Errorf("failed to expand query: %w", err)
+	}
+	return jql, nil
+}
+
+// runSavedQuery runs a query and lets the user pick an issue from the results
+func runSavedQuery(cfg *config.Config, client *jira.Client, query *config.Query, params map[string]string) error {
+	res, err := fetchQuery(cfg, client, query, params, false)
+	if err != nil {
+		if err == ErrPromptCancelled {
+			fmt.Println("\nCancelled.")
+			return nil
+		}
+		return err
+	}
+
+	if len(res.issues) == 0 {
+		fmt.Println("No issues found.")
+		return nil
+	}
+
+	return selectIssue(cfg, client, res.issues, res.columns)
+}
+
+// queryResults is the outcome of running a query
+type queryResults struct {
+	jql     string
+	columns []issueColumn
+	fields  []string
+	issues  []jira.RawIssue
+}
+
+// fetchQuery expands a query's JQL and fetches the fields for its columns. With all set,
+// the query's limit is ignored and every matching issue is fetched.
+func fetchQuery(cfg *config.Config, client *jira.Client, query *config.Query, params map[string]string, all bool) (*queryResults, error) {
+	// Expand variables, parameters and custom field names in JQL
+	jql, err := expandQuery(cfg, client, query, params)
+	if err != nil {
+		return nil, err
+	}
 
 	limit := query.Limit
 	if limit <= 0 {
 		limit = 50
 	}
+	if all {
+		limit = 0
+	}
 
-	fmt.Printf("Running query: %s\n", queryName)
+	fmt.Printf("Running query: %s\n", query.Name)
 	fmt.Printf("JQL: %s\n\n", jql)
 
-	issues, err := client.SearchIssues(jql, limit)
+	columns, err := resolveColumns(client, query.Columns)
 	if err != nil {
-		return fmt.Errorf("query failed: %w", err)
+		return nil, err
+	}
+	// --since-last labels issues by summary and uses "updated" to spot changes to fields that are not shown
+	fields, err := columnFieldIDs(client, columns, append(append([]string(nil), query.Fields...), "summary", "updated"))
+	if err != nil {
+		return nil, err
 	}
 
-	if len(issues) == 0 {
-		fmt.Println("No issues found.")
+	var issues []jira.RawIssue
+	err = client.SearchAll(jql, jira.SearchOptions{Fields: fields, Limit: limit}, func(page []jira.RawIssue) error {
+		issues = append(issues, page...)
 		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
 	}
 
-	// Display issues in a select menu
-	items := make([]string, len(issues))
-	for i, issue := range issues {
-		status := ""
-		if issue.Fields != nil && issue.Fields.Status != nil {
-			status = issue.Fields.Status.Name
-		}
-		summary := ""
-		if issue.Fields != nil {
-			summary = issue.Fields.Summary
-		}
-		// Truncate summary if too long
-		if len(summary) > 60 {
-			summary = summary[:57] + "..."
-		}
-		items[i] = fmt.Sprintf("%-12s %-15s %s", issue.Key, status, summary)
-	}
+	return &queryResults{jql: jql, columns: columns, fields: fields, issues: issues}, nil
+}
+
+// selectIssue shows issues in a picker and prints the details of the selected one, or
+// creates a git branch for it with --branch or the branch_action menu entry
+func selectIssue(cfg *config.Config, client *jira.Client, issues []jira.RawIssue, columns []issueColumn) error {
+	items := formatIssueLines(issues, columns)
 
 	idx, err := fzfSelect(items, fmt.Sprintf("Select issue (%d found)", len(issues)))
 	if err != nil {
@@ -92,9 +247,57 @@ func runQuery(queryName string) error {
 		return fmt.Errorf("prompt failed: %w", err)
 	}
 
-	// Show selected issue details
-	selected := issues[idx]
-	return showIssueDetails(client, cfg.Server, selected.Key)
+	key := issues[idx].Key
+	branch := queryBranch
+	// The action menu is opt-in so picking an issue stays a single step by default
+	if !branch && cfg.Git.BranchAction && git.InWorkTree() {
+		actions := []string{"Show details", "Create git branch"}
+		action, err := fzfSelect(actions, key)
+		if err != nil {
+			if err == fuzzyfinder.ErrAbort {
+				return nil
+			}
+			return fmt.Errorf("prompt failed: %w", err)
+		}
+		branch = action == 1
+	}
+
+	if branch {
+		opts := branchOptions{start: cfg.Git.Start, assign: cfg.Git.Assign}
+		if queryRunCmd != nil {
+			opts = branchOptionsFromFlags(queryRunCmd, cfg, queryStart, queryAssign)
+		}
+		return startBranch(cfg, client, key, opts)
+	}
+	return showIssueDetails(client, cfg.Server, key)
+}
+
+// resolveQueryParams returns a value for every parameter in jql, taken from flags,
+// JIRACTL_PARAM_<NAME> environment variables, the parameter's default, or a prompt
+func resolveQueryParams(cfg *config.Config, jql string, flags map[string]string) (map[string]string, error) {
+	params := make(map[string]string, len(flags))
+	for name, value := range flags {
+		params[name] = value
+	}
+
+	for _, p := range cfg.QueryParams(jql) {
+		if _, ok := params[p.Name]; ok {
+			continue
+		}
+		if value, ok := os.LookupEnv("JIRACTL_PARAM_" + strings.ToUpper(p.Name)); ok {
+			params[p.Name] = value
+			continue
+		}
+		if p.HasDefault {
+			continue
+		}
+		value, err := promptText(p.Name, true)
+		if err != nil {
+			return nil, err
+		}
+		params[p.Name] = value
+	}
+	return params, nil
 }
 
 func showIssueDetails(client *jira.Client, server, key string) error {
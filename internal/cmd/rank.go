@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/reorder"
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+	"github.com/spf13/cobra"
+)
+
+var rankCmd = &cobra.Command{
+	Use:   "rank [key]...",
+	Short: "Change the rank of issues",
+	Long: `Move issues directly before or after another issue, or to the top or bottom of the
+board's backlog. Several issues are moved together, in the order given. Without a
+key, the issue key in the current git branch name is used.
+
+  jiractl rank PROJ-12 --before PROJ-7
+  jiractl rank PROJ-12 PROJ-13 --after PROJ-7
+  jiractl rank PROJ-12 --top -b "PROJ board"
+
+To reorder the backlog interactively, use "jiractl sprint backlog --reorder".`,
+	RunE: runRank,
+}
+
+var (
+	rankBefore string
+	rankAfter  string
+	rankTop    bool
+	rankBottom bool
+	rankBoard  string
+)
+
+func init() {
+	RootCmd.AddCommand(rankCmd)
+	rankCmd.Flags().StringVar(&rankBefore, "before", "", "Rank the issues directly before this issue")
+	rankCmd.Flags().StringVar(&rankAfter, "after", "", "Rank the issues directly after this issue")
+	rankCmd.Flags().BoolVar(&rankTop, "top", false, "Move the issues to the top of the backlog")
+	rankCmd.Flags().BoolVar(&rankBottom, "bottom", false, "Move the issues to the bottom of the backlog")
+	rankCmd.Flags().StringVarP(&rankBoard, "board", "b", "", "Board whose backlog --top and --bottom refer to")
+	rankCmd.MarkFlagsMutuallyExclusive("before", "after", "top", "bottom")
+	rankCmd.MarkFlagsOneRequired("before", "after", "top", "bottom")
+}
+
+func runRank(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, len(args))
+	for i, arg := range args {
+		keys[i] = strings.ToUpper(arg)
+	}
+	if len(keys) == 0 {
+		key, err := issueKeyArg(cfg, nil)
+		if err != nil {
+			return err
+		}
+		keys = []string{key}
+	}
+	before, after := strings.ToUpper(rankBefore), strings.ToUpper(rankAfter)
+	if slices.Contains(keys, before) || slices.Contains(keys, after) {
+		return fmt.Errorf("cannot rank an issue relative to itself")
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	if rankTop || rankBottom {
+		board, err := resolveBoard(cfg, client, rankBoard)
+		if err != nil {
+			if err == fuzzyfinder.ErrAbort {
+				return nil
+			}
+			return err
+		}
+		before, after, err = backlogEnd(client, board, keys, rankTop)
+		if err != nil {
+			return err
+		}
+		if before == "" && after == "" {
+			fmt.Println("Nothing to rank against: the backlog has no other issues.")
+			return nil
+		}
+	}
+
+	if err := client.RankIssues(keys, before, after); err != nil {
+		return err
+	}
+
+	target := "before " + before
+	if after != "" {
+		target = "after " + after
+	}
+	fmt.Printf("Ranked %s %s\n", strings.Join(keys, ", "), target)
+	return nil
+}
+
+// backlogEnd returns the first (top) or last backlog issue that is not being moved,
+// as the issue to rank before or after
+func backlogEnd(client *jira.Client, board *jira.Board, moving []string, top bool) (before, after string, err error) {
+	// The top only needs as many issues as are being moved, plus one
+	limit := 0
+	if top {
+		limit = len(moving) + 1
+	}
+	issues, err := client.GetBacklog(board.ID, []string{"summary"}, limit)
+	if err != nil {
+		return "", "", err
+	}
+
+	var others []string
+	for _, issue := range issues {
+		if !slices.Contains(moving, issue.Key) {
+			others = append(others, issue.Key)
+		}
+	}
+	switch {
+	case len(others) == 0:
+		return "", "", nil
+	case top:
+		return others[0], "", nil
+	}
+	return "", others[len(others)-1], nil
+}
+
+// applyRankMoves runs the moves planned by reorder.Plan
+func applyRankMoves(client *jira.Client, moves []reorder.Move) error {
+	for _, m := range moves {
+		if err := client.RankIssues(m.Keys, m.Before, m.After); err != nil {
+			return err
+		}
+	}
+	return nil
+}
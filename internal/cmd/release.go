@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/git"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/releasenotes"
+	"github.com/spf13/cobra"
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Prepare releases",
+}
+
+var releaseNotesCmd = &cobra.Command{
+	Use:   "notes <version>",
+	Short: "Generate release notes from a fix version",
+	Long: `Generate release notes from the issues whose fix version is <version>, grouped by
+issue type or label.
+
+Notes are rendered with a Go template. To customise one, save the built-in template
+and edit it; templates in the release-notes directory of the jiractl config directory
+(e.g. ~/.config/jiractl/release-notes/markdown.tmpl) replace the built-in ones:
+
+  jiractl release notes --print-template --format html > my-notes.html.tmpl
+  jiractl release notes 2.4.0 --template my-notes.html.tmpl --format html -o notes.html
+
+With --from, the issue keys in the commit messages between two git refs are checked
+against the version, and commits and issues that don't match are listed on stderr:
+
+  jiractl release notes 2.4.0 --from v2.3.0 --to v2.4.0`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReleaseNotes,
+}
+
+var (
+	releaseProject       string
+	releaseGroupBy       string
+	releaseFormat        string
+	releaseTemplate      string
+	releaseOutput        string
+	releasePrintTemplate bool
+	releaseFrom          string
+	releaseTo            string
+)
+
+func init() {
+	RootCmd.AddCommand(releaseCmd)
+	releaseCmd.AddCommand(releaseNotesCmd)
+	releaseNotesCmd.Flags().StringVarP(&releaseProject, "project", "p", "", "Project key (default from config)")
+	releaseNotesCmd.Flags().StringVar(&releaseGroupBy, "group-by", releasenotes.GroupByType, "Group issues by type or label")
+	releaseNotesCmd.Flags().StringVar(&releaseFormat, "format", releasenotes.FormatMarkdown, "Output format: markdown, html or text")
+	releaseNotesCmd.Flags().StringVarP(&releaseTemplate, "template", "t", "", "Go template file to render the notes with")
+	releaseNotesCmd.Flags().StringVarP(&releaseOutput, "output", "o", "", "Output file (default stdout)")
+	releaseNotesCmd.Flags().BoolVar(&releasePrintTemplate, "print-template", false, "Print the template for --format and exit")
+	releaseNotesCmd.Flags().StringVar(&releaseFrom, "from", "", "Git ref of the previous release, to cross-check commits")
+	releaseNotesCmd.Flags().StringVar(&releaseTo, "to", "HEAD", "Git ref of this release")
+}
+
+func runReleaseNotes(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	tmpl, err := releaseNotesTemplate(releaseFormat, releaseTemplate)
+	if err != nil {
+		return err
+	}
+	if releasePrintTemplate {
+		if tmpl == "" {
+			tmpl, err = releasenotes.DefaultTemplate(releaseFormat)
+			if err != nil {
+				return err
+			}
+		}
+		fmt.Print(tmpl)
+		return nil
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("requires a version")
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	project := releaseProject
+	if project == "" {
+		project = cfg.Project
+	}
+	version, err := resolveVersion(client, project, args[0])
+	if err != nil {
+		return err
+	}
+
+	jql := fmt.Sprintf(`project = "%s" AND fixVersion = %s ORDER BY key`, project, version.ID)
+	raw, err := searchAllIssues(client, jql, jira.SearchOptions{Fields: releasenotes.Fields})
+	if err != nil {
+		return err
+	}
+	issues := make([]releasenotes.Issue, len(raw))
+	for i, issue := range raw {
+		issues[i] = releasenotes.NewIssue(issue, cfg.Server)
+	}
+
+	notes, err := releasenotes.New(project, releasenotes.Version{
+		Name:        version.Name,
+		Description: version.Description,
+		ReleaseDate: version.ReleaseDate,
+		Released:    version.Released != nil && *version.Released,
+	}, issues, releaseGroupBy)
+	if err != nil {
+		return err
+	}
+
+	if releaseFrom != "" {
+		if err := crossCheckRelease(notes.Issues, releaseFrom, releaseTo); err != nil {
+			return err
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if releaseOutput != "" {
+		f, err := os.Create(releaseOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	return releasenotes.Write(out, notes, releaseFormat, tmpl)
+}
+
+// releaseNotesTemplate reads the template file given, or the user's template for the
+// format. It returns "" when the built-in template should be used.
+func releaseNotesTemplate(format, path string) (string, error) {
+	if path == "" {
+		if _, err := releasenotes.DefaultTemplate(format); err != nil {
+			return "", err
+		}
+		dir, err := config.ReleaseNotesDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(dir, format+".tmpl")
+		if !fileExists(path) {
+			return "", nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	return string(data), nil
+}
+
+// crossCheckRelease warns on stderr about commits between from and to whose issue keys
+// are not in the release, and about issues without commits
+func crossCheckRelease(issues []releasenotes.Issue, from, to string) error {
+	commits, err := git.Log(from, to)
+	if err != nil {
+		return err
+	}
+	outside, unreferenced, uncommitted := releasenotes.CrossCheck(issues, commits)
+
+	w := os.Stderr
+	fmt.Fprintf(w, "Checked %d commits in %s..%s against %d issues\n", len(commits), from, to, len(issues))
+	if len(outside) > 0 {
+		fmt.Fprintf(w, "\nWarning: %d commits reference issues not in this version:\n", len(outside))
+		for _, m := range outside {
+			fmt.Fprintf(w, "  %s %s (%s)\n", shortHash(m.Commit.Hash), m.Commit.Subject, strings.Join(m.Keys, ", "))
+		}
+	}
+	if len(unreferenced) > 0 {
+		fmt.Fprintf(w, "\nWarning: %d commits reference no issue:\n", len(unreferenced))
+		for _, c := range unreferenced {
+			fmt.Fprintf(w, "  %s %s\n", shortHash(c.Hash), c.Subject)
+		}
+	}
+	if len(uncommitted) > 0 {
+		fmt.Fprintf(w, "\nWarning: %d issues have no commits:\n", len(uncommitted))
+		for _, issue := range uncommitted {
+			fmt.Fprintf(w, "  %s %s\n", issue.Key, issue.Summary)
+		}
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/chzyer/readline"
+	"github.com/eugenetaranov/jiractl/internal/config"
 	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +26,30 @@ func fzfSelect(items []string, prompt ...string) (int, error) {
 	}, opts...)
 }
 
+// fzfSelectMulti provides a multi-selection UI (Tab to toggle) with a header showing the prompt
+func fzfSelectMulti(items []string, prompt ...string) ([]int, error) {
+	opts := []fuzzyfinder.Option{}
+	if len(prompt) > 0 && prompt[0] != "" {
+		opts = append(opts, fuzzyfinder.WithHeader(prompt[0]))
+	}
+	return fuzzyfinder.FindMulti(items, func(i int) string {
+		return items[i]
+	}, opts...)
+}
+
+// parseKeyValueFlags turns repeated "name=value" flag values into a map
+func parseKeyValueFlags(values []string) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid value %q, expected name=value", v)
+		}
+		result[strings.TrimSpace(name)] = value
+	}
+	return result, nil
+}
+
 // promptText prompts for text input with readline support (Ctrl+W, etc.)
 func promptText(label string, required bool) (string, error) {
 	return promptTextWithDefault(label, "", required)
@@ -32,12 +57,17 @@ func promptText(label string, required bool) (string, error) {
 
 // promptTextWithDefault prompts for text input with a default value
 func promptTextWithDefault(label, defaultVal string, required bool) (string, error) {
+	return promptTextWithCompleter(label, defaultVal, required, nil)
+}
+
+// promptTextWithCompleter prompts for text input with tab completion from completer (may be nil)
+func promptTextWithCompleter(label, defaultVal string, required bool, completer readline.AutoCompleter) (string, error) {
 	prompt := label + ": "
 	if defaultVal != "" {
 		prompt = label + " [" + defaultVal + "]: "
 	}
 
-	rl, err := readline.New(prompt)
+	rl, err := readline.NewEx(&readline.Config{Prompt: prompt, AutoComplete: completer})
 	if err != nil {
 		return "", err
 	}
@@ -145,6 +175,7 @@ func runInteractiveMenu(cmd *cobra.Command, args []string) error {
 	menuItems := []string{
 		"Create new issue",
 		"Run query",
+		"Search with JQL",
 		"Configure",
 		"Exit",
 	}
@@ -159,19 +190,53 @@ func runInteractiveMenu(cmd *cobra.Command, args []string) error {
 
 	switch idx {
 	case 0: // Create new issue
+		selected, err := selectTemplate()
+		if err != nil {
+			if err == fuzzyfinder.ErrAbort {
+				return nil
+			}
+			return fmt.Errorf("prompt failed: %w", err)
+		}
+		createTemplate = selected
 		return createCmd.RunE(createCmd, nil)
 	case 1: // Run query
-		return runQueryInteractive()
-	case 2: // Configure
+		return runQueryInteractive(nil)
+	case 2: // Search with JQL
+		return runSearch("")
+	case 3: // Configure
 		return configureCmd.RunE(configureCmd, nil)
-	case 3: // Exit
+	case 4: // Exit
 		return nil
 	}
 
 	return nil
 }
 
-func runQueryInteractive() error {
+// selectTemplate offers the configured issue templates, returning "" for no template
+func selectTemplate() (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	names := cfg.TemplateNames()
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	items := append([]string{"(No template)"}, names...)
+	idx, err := fzfSelect(items, "Select template")
+	if err != nil {
+		return "", err
+	}
+	if idx == 0 {
+		return "", nil
+	}
+	return names[idx-1], nil
+}
+
+// runQueryInteractive lets the user pick a saved query and runs it with params
+func runQueryInteractive(params map[string]string) error {
 	cfg, err := loadConfig()
 	if err != nil {
 		return err
@@ -191,7 +256,7 @@ func runQueryInteractive() error {
 		return fmt.Errorf("prompt failed: %w", err)
 	}
 
-	return runQuery(names[idx])
+	return runQuery(names[idx], params)
 }
 
 func Execute() {
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search [jql]",
+	Short: "Run an ad-hoc JQL search",
+	Long: `Run a JQL search without saving it as a query.
+
+Without an argument, prompts for JQL with tab completion of field names,
+operators, functions and values.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSearchCmd,
+}
+
+var searchLimit int
+
+func init() {
+	RootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "Maximum number of results")
+}
+
+func runSearchCmd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	jql := ""
+	if len(args) > 0 {
+		jql = args[0]
+	}
+	return runSearch(jql)
+}
+
+// runSearch runs an ad-hoc search, prompting for the JQL if it is empty
+func runSearch(jql string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	if jql == "" {
+		jql, err = promptJQL(client, "JQL", "")
+		if err != nil {
+			if err == ErrPromptCancelled {
+				fmt.Println("\nCancelled.")
+				return nil
+			}
+			return err
+		}
+	}
+
+	query := &config.Query{Name: "search", JQL: jql, Limit: searchLimit}
+	return runSavedQuery(cfg, client, query, nil)
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/cache"
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/watch"
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// maxDiffValueWidth keeps long values such as descriptions from flooding the diff
+const maxDiffValueWidth = 60
+
+// queryStateName is the state entry holding the last results of a saved query
+func queryStateName(query *config.Query) string {
+	return "query_" + query.Name
+}
+
+// recordQueryResults saves the results of a saved query for the next --since-last run.
+// Only --since-last runs record, so plain runs don't move the baseline. Filters and
+// ad-hoc searches are not recorded.
+func recordQueryResults(cfg *config.Config, query *config.Query, res *queryResults) {
+	if cfg.GetQuery(query.Name) != query {
+		return
+	}
+	_ = cache.SaveState(cfg.Server, queryStateName(query), watch.NewResultSet(res.jql, res.issues, res.fields))
+}
+
+// runSinceLast shows the issues that are new, removed or updated since the previous
+// --since-last run of a saved query, then lets the user pick one of the current issues.
+// The query's limit is ignored so that issues past it don't show up as removed.
+func runSinceLast(cfg *config.Config, client *jira.Client, query *config.Query, params map[string]string) error {
+	var prev watch.ResultSet
+	found, err := cache.LoadState(cfg.Server, queryStateName(query), &prev)
+	if err != nil {
+		return err
+	}
+
+	res, err := fetchQuery(cfg, client, query, params, true)
+	if err != nil {
+		if err == ErrPromptCancelled {
+			fmt.Println("\nCancelled.")
+			return nil
+		}
+		return err
+	}
+	recordQueryResults(cfg, query, res)
+
+	if !found {
+		fmt.Printf("No previous run recorded; saved %d issues for next time.\n", len(res.issues))
+		return nil
+	}
+
+	fmt.Printf("Since %s:\n", prev.RunAt.Format("2006-01-02 15:04"))
+	if prev.JQL != res.jql {
+		fmt.Println("Note: the JQL differs from the previous run (changed query or parameters).")
+	}
+
+	cur := watch.NewResultSet(res.jql, res.issues, res.fields)
+	diffs := watch.DiffResults(prev, cur)
+	if len(diffs) == 0 {
+		fmt.Println("No changes.")
+		return nil
+	}
+
+	names := make(map[string]string, len(res.fields))
+	for _, c := range res.columns {
+		names[c.ID] = c.Name
+	}
+	for _, id := range res.fields {
+		if _, ok := names[id]; !ok {
+			if f, err := client.ResolveField(id); err == nil {
+				names[id] = f.Name
+			}
+		}
+	}
+
+	var changed []jira.RawIssue
+	byKey := make(map[string]jira.RawIssue, len(res.issues))
+	for _, issue := range res.issues {
+		byKey[issue.Key] = issue
+	}
+
+	counts := map[string]int{}
+	for _, d := range diffs {
+		counts[d.Type]++
+		printIssueDiff(d, names)
+		if d.Type != watch.Left {
+			changed = append(changed, byKey[d.Key])
+		}
+	}
+	fmt.Printf("\n%d new, %d updated, %d removed\n\n", counts[watch.Entered], counts[watch.Updated], counts[watch.Left])
+
+	if len(changed) == 0 {
+		return nil
+	}
+	return selectIssue(cfg, client, changed, res.columns)
+}
+
+// printIssueDiff prints one issue's change with a line per changed field
+func printIssueDiff(d watch.IssueDiff, names map[string]string) {
+	marker := map[string]string{watch.Entered: "+", watch.Left: "-", watch.Updated: "~"}[d.Type]
+	fmt.Printf("%s %s  %s\n", marker, d.Key, d.Fields["summary"])
+
+	shown := 0
+	for _, c := range d.Changes {
+		// The timestamp only matters when nothing visible changed
+		if c.Field == "updated" {
+			continue
+		}
+		name := names[c.Field]
+		if name == "" {
+			name = c.Field
+		}
+		fmt.Printf("    %s: %s -> %s\n", name, diffValue(c.Old), diffValue(c.New))
+		shown++
+	}
+	if d.Type == watch.Updated && shown == 0 {
+		fmt.Printf("    updated %s\n", d.Fields["updated"])
+	}
+}
+
+func diffValue(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return runewidth.Truncate(orNone(s), maxDiffValueWidth, "...")
+}
@@ -0,0 +1,565 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/reorder"
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+	"github.com/spf13/cobra"
+)
+
+// sprintIssueColumns are shown when listing the issues in a sprint or backlog
+var sprintIssueColumns = []string{keyColumn, "issuetype", "status", "assignee", "summary"}
+
+// backlogTarget is the --move-to value that moves incomplete issues to the backlog
+const backlogTarget = "backlog"
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Work with sprints",
+	Long: `Work with the sprints of a scrum board.
+
+The board is taken from --board, the board setting in the config file, or the
+configured project's boards. Sprints are referenced by ID or name.`,
+}
+
+var sprintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sprints",
+	Args:  cobra.NoArgs,
+	RunE:  runSprintList,
+}
+
+var sprintShowCmd = &cobra.Command{
+	Use:   "show <sprint>",
+	Short: "Show a sprint and its issues",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSprintShow,
+}
+
+var sprintCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the active sprint and its issues",
+	Args:  cobra.NoArgs,
+	RunE:  runSprintCurrent,
+}
+
+var sprintStartCmd = &cobra.Command{
+	Use:   "start <sprint>",
+	Short: "Start a future sprint",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSprintStart,
+}
+
+var sprintCloseCmd = &cobra.Command{
+	Use:   "close <sprint>",
+	Short: "Close an active sprint",
+	Long: `Close an active sprint. Incomplete issues are moved to the sprint given with
+--move-to (or "backlog") once the sprint is closed; without it you are asked where to
+move them. You are asked to confirm unless --yes is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSprintClose,
+}
+
+var sprintAddCmd = &cobra.Command{
+	Use:   "add <sprint> <key>...",
+	Short: "Move issues into a sprint",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runSprintAdd,
+}
+
+var sprintBacklogCmd = &cobra.Command{
+	Use:   "backlog",
+	Short: "List backlog issues in rank order",
+	Args:  cobra.NoArgs,
+	RunE:  runSprintBacklog,
+}
+
+var (
+	sprintBoard        string
+	sprintStates       []string
+	sprintWeeks        int
+	sprintEnd          string
+	sprintGoal         string
+	sprintMoveTo       string
+	sprintYes          bool
+	sprintBacklogLimit int
+	sprintReorder      bool
+)
+
+func init() {
+	RootCmd.AddCommand(sprintCmd)
+	sprintCmd.AddCommand(sprintListCmd)
+	sprintCmd.AddCommand(sprintShowCmd)
+	sprintCmd.AddCommand(sprintCurrentCmd)
+	sprintCmd.AddCommand(sprintStartCmd)
+	sprintCmd.AddCommand(sprintCloseCmd)
+	sprintCmd.AddCommand(sprintAddCmd)
+	sprintCmd.AddCommand(sprintBacklogCmd)
+
+	sprintCmd.PersistentFlags().StringVarP(&sprintBoard, "board", "b", "", "Board ID or name")
+	sprintListCmd.Flags().StringSliceVar(&sprintStates, "state", []string{jira.SprintActive, jira.SprintFuture}, "Sprint states to list: active, future, closed")
+	sprintStartCmd.Flags().IntVar(&sprintWeeks, "weeks", 2, "Sprint length in weeks")
+	sprintStartCmd.Flags().StringVar(&sprintEnd, "end", "", "End date (YYYY-MM-DD), instead of --weeks")
+	sprintStartCmd.Flags().StringVar(&sprintGoal, "goal", "", "Sprint goal")
+	sprintCloseCmd.Flags().StringVar(&sprintMoveTo, "move-to", "", `Sprint for incomplete issues, or "backlog"`)
+	sprintCloseCmd.Flags().BoolVarP(&sprintYes, "yes", "y", false, "Don't ask for confirmation")
+	sprintBacklogCmd.Flags().IntVar(&sprintBacklogLimit, "limit", 50, "Maximum number of issues (0 for all)")
+	sprintBacklogCmd.Flags().BoolVar(&sprintReorder, "reorder", false, "Reorder the issues interactively and save the new ranking")
+}
+
+// newSprintClient loads the config and resolves the board for a sprint command
+func newSprintClient() (*config.Config, *jira.Client, *jira.Board, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	board, err := resolveBoard(cfg, client, sprintBoard)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cfg, client, board, nil
+}
+
+func runSprintList(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, board, err := newSprintClient()
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	sprints, err := client.GetSprints(board.ID, sprintStates...)
+	if err != nil {
+		return err
+	}
+	if len(sprints) == 0 {
+		fmt.Println("No sprints found.")
+		return nil
+	}
+
+	for _, s := range sprints {
+		fmt.Println(formatSprintLine(s))
+	}
+	return nil
+}
+
+func runSprintShow(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, board, err := newSprintClient()
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	sprint, err := resolveSprint(client, board, args[0])
+	if err != nil {
+		return err
+	}
+	return showSprint(client, sprint)
+}
+
+func runSprintCurrent(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, board, err := newSprintClient()
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	sprints, err := client.GetSprints(board.ID, jira.SprintActive)
+	if err != nil {
+		return err
+	}
+	if len(sprints) == 0 {
+		fmt.Printf("No active sprint on %s.\n", board.Name)
+		return nil
+	}
+
+	// Boards with parallel sprints can have more than one active sprint
+	for i := range sprints {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := showSprint(client, &sprints[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runSprintStart(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, board, err := newSprintClient()
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	sprint, err := resolveSprint(client, board, args[0])
+	if err != nil {
+		return err
+	}
+	if sprint.State != jira.SprintFuture {
+		return fmt.Errorf("sprint %s is %s; only future sprints can be started", sprint.Name, sprint.State)
+	}
+
+	end := time.Now().AddDate(0, 0, 7*sprintWeeks)
+	if sprintEnd != "" {
+		end, err = time.ParseInLocation("2006-01-02", sprintEnd, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --end date %q, expected YYYY-MM-DD", sprintEnd)
+		}
+		// Run until the end of the given day
+		end = end.Add(24*time.Hour - time.Minute)
+	}
+	if !end.After(time.Now()) {
+		return fmt.Errorf("end date must be in the future")
+	}
+
+	started, err := client.StartSprint(sprint.ID, end, sprintGoal)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Sprint started: %s\n", formatSprintLine(*started))
+	return nil
+}
+
+func runSprintClose(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, board, err := newSprintClient()
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	sprint, err := resolveSprint(client, board, args[0])
+	if err != nil {
+		return err
+	}
+	if sprint.State != jira.SprintActive {
+		return fmt.Errorf("sprint %s is %s; only active sprints can be closed", sprint.Name, sprint.State)
+	}
+
+	issues, err := client.GetSprintIssues(sprint.ID, "", []string{"status"})
+	if err != nil {
+		return err
+	}
+	var incomplete []string
+	for _, issue := range issues {
+		if !issueDone(issue) {
+			incomplete = append(incomplete, issue.Key)
+		}
+	}
+
+	fmt.Printf("%s: %d of %d issues done\n", sprint.Name, len(issues)-len(incomplete), len(issues))
+
+	// Resolve where incomplete issues go before anything changes
+	var next *jira.Sprint
+	target := sprintMoveTo
+	if len(incomplete) > 0 {
+		if target == "" {
+			target, err = selectMoveTarget(client, board, sprint)
+			if err != nil {
+				if err == fuzzyfinder.ErrAbort {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+				return err
+			}
+		}
+		if strings.EqualFold(target, backlogTarget) {
+			target = backlogTarget
+		} else {
+			next, err = resolveSprint(client, board, target)
+			if err != nil {
+				return err
+			}
+			if next.ID == sprint.ID {
+				return fmt.Errorf("cannot move incomplete issues into the sprint being closed")
+			}
+			target = next.Name
+		}
+	}
+
+	if !sprintYes {
+		label := fmt.Sprintf("Close sprint %q?", sprint.Name)
+		if len(incomplete) > 0 {
+			label = fmt.Sprintf("Close sprint %q and move %d incomplete issues to %s?", sprint.Name, len(incomplete), target)
+		}
+		confirmed, err := promptConfirm(label)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Close first so Jira records the issues as incomplete at the end of the sprint
+	// rather than removed from it, which keeps sprint reports and velocity right
+	if _, err := client.CloseSprint(sprint.ID); err != nil {
+		return err
+	}
+	fmt.Printf("Sprint closed: %s\n", sprint.Name)
+
+	if len(incomplete) == 0 {
+		return nil
+	}
+	if next != nil {
+		err = client.MoveIssuesToSprint(next.ID, incomplete)
+	} else {
+		err = client.MoveIssuesToBacklog(incomplete)
+	}
+	if err != nil {
+		return fmt.Errorf("sprint closed, but moving incomplete issues failed: %w", err)
+	}
+	fmt.Printf("Moved %d incomplete issues to %s\n", len(incomplete), target)
+	return nil
+}
+
+// selectMoveTarget asks where incomplete issues should go when a sprint is closed
+func selectMoveTarget(client *jira.Client, board *jira.Board, closing *jira.Sprint) (string, error) {
+	sprints, err := client.GetSprints(board.ID, jira.SprintActive, jira.SprintFuture)
+	if err != nil {
+		return "", err
+	}
+
+	targets := []string{backlogTarget}
+	items := []string{"Backlog"}
+	for _, s := range sprints {
+		if s.ID == closing.ID {
+			continue
+		}
+		targets = append(targets, strconv.Itoa(s.ID))
+		items = append(items, formatSprintLine(s))
+	}
+
+	idx, err := fzfSelect(items, "Move incomplete issues to")
+	if err != nil {
+		return "", err
+	}
+	return targets[idx], nil
+}
+
+func runSprintAdd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, board, err := newSprintClient()
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	sprint, err := resolveSprint(client, board, args[0])
+	if err != nil {
+		return err
+	}
+	if sprint.State == jira.SprintClosed {
+		return fmt.Errorf("sprint %s is closed", sprint.Name)
+	}
+
+	keys := make([]string, len(args)-1)
+	for i, key := range args[1:] {
+		keys[i] = strings.ToUpper(key)
+	}
+	if err := client.MoveIssuesToSprint(sprint.ID, keys); err != nil {
+		return err
+	}
+
+	fmt.Printf("Moved %d issues to %s\n", len(keys), sprint.Name)
+	return nil
+}
+
+func runSprintBacklog(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, board, err := newSprintClient()
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	columns, err := resolveColumns(client, sprintIssueColumns)
+	if err != nil {
+		return err
+	}
+	fields, err := columnFieldIDs(client, columns, nil)
+	if err != nil {
+		return err
+	}
+
+	issues, err := client.GetBacklog(board.ID, fields, sprintBacklogLimit)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Println("Backlog is empty.")
+		return nil
+	}
+
+	lines := formatIssueLines(issues, columns)
+	if sprintReorder {
+		return reorderBacklog(client, board, issues, lines)
+	}
+
+	width := len(strconv.Itoa(len(issues)))
+	for i, line := range lines {
+		fmt.Printf("%*d. %s\n", width, i+1, line)
+	}
+	return nil
+}
+
+// reorderBacklog lets the user rearrange backlog issues and saves the new order in one go
+func reorderBacklog(client *jira.Client, board *jira.Board, issues []jira.RawIssue, lines []string) error {
+	items := make([]reorder.Item, len(issues))
+	original := make([]string, len(issues))
+	for i, issue := range issues {
+		items[i] = reorder.Item{Key: issue.Key, Line: lines[i]}
+		original[i] = issue.Key
+	}
+
+	reordered, save, err := reorder.Run(fmt.Sprintf("%s backlog", board.Name), items)
+	if err != nil {
+		return err
+	}
+	if !save {
+		fmt.Println("Backlog unchanged.")
+		return nil
+	}
+
+	keys := make([]string, len(reordered))
+	for i, item := range reordered {
+		keys[i] = item.Key
+	}
+	moves := reorder.Plan(original, keys)
+	if err := applyRankMoves(client, moves); err != nil {
+		return err
+	}
+
+	moved := 0
+	for _, m := range moves {
+		moved += len(m.Keys)
+	}
+	fmt.Printf("Backlog reordered: %d issues ranked.\n", moved)
+	return nil
+}
+
+// resolveSprint finds one of the board's sprints by ID or name (case-insensitive)
+func resolveSprint(client *jira.Client, board *jira.Board, arg string) (*jira.Sprint, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return client.GetSprint(id)
+	}
+
+	sprints, err := client.GetSprints(board.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []jira.Sprint
+	for _, s := range sprints {
+		if strings.EqualFold(s.Name, arg) {
+			matches = append(matches, s)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("sprint not found on %s: %s", board.Name, arg)
+	case 1:
+		return &matches[0], nil
+	}
+	return nil, fmt.Errorf("sprint name %q is ambiguous; use its ID", arg)
+}
+
+// showSprint prints a sprint's details and its issues in rank order
+func showSprint(client *jira.Client, sprint *jira.Sprint) error {
+	fmt.Printf("%s (%d)\n", sprint.Name, sprint.ID)
+	fmt.Printf("─────────────────────────────────────────────────────────\n")
+	fmt.Printf("State:       %s\n", sprint.State)
+	if sprint.StartDate != nil {
+		fmt.Printf("Start:       %s\n", sprint.StartDate.Local().Format("2006-01-02"))
+	}
+	if sprint.EndDate != nil {
+		fmt.Printf("End:         %s\n", sprint.EndDate.Local().Format("2006-01-02"))
+	}
+	if sprint.CompleteDate != nil {
+		fmt.Printf("Completed:   %s\n", sprint.CompleteDate.Local().Format("2006-01-02"))
+	}
+	if sprint.Goal != "" {
+		fmt.Printf("Goal:        %s\n", sprint.Goal)
+	}
+
+	columns, err := resolveColumns(client, sprintIssueColumns)
+	if err != nil {
+		return err
+	}
+	fields, err := columnFieldIDs(client, columns, nil)
+	if err != nil {
+		return err
+	}
+
+	issues, err := client.GetSprintIssues(sprint.ID, "", fields)
+	if err != nil {
+		return err
+	}
+
+	done := 0
+	for _, issue := range issues {
+		if issueDone(issue) {
+			done++
+		}
+	}
+	fmt.Printf("Issues:      %d (%d done)\n\n", len(issues), done)
+
+	for _, line := range formatIssueLines(issues, columns) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// formatSprintLine renders a sprint as a single line with its state and dates
+func formatSprintLine(s jira.Sprint) string {
+	dates := ""
+	if s.StartDate != nil && s.EndDate != nil {
+		dates = fmt.Sprintf("  %s - %s", s.StartDate.Local().Format("2006-01-02"), s.EndDate.Local().Format("2006-01-02"))
+	}
+	return fmt.Sprintf("%-6d %-7s %s%s", s.ID, s.State, s.Name, dates)
+}
+
+// issueDone reports whether an issue's status is in the Done category
+func issueDone(issue jira.RawIssue) bool {
+	status, _ := issue.Fields["status"].(map[string]interface{})
+	category, _ := status["statusCategory"].(map[string]interface{})
+	return category["key"] == "done"
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/report"
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+	"github.com/spf13/cobra"
+)
+
+var sprintReportCmd = &cobra.Command{
+	Use:   "report [sprint]",
+	Short: "Summarise a sprint with a burndown chart",
+	Long: `Summarise a sprint: committed and completed issues and story points, scope
+added or removed after the sprint started, and a burndown chart.
+
+Issues count as completed when their status is in the board's last column. Story
+points come from the board's estimation field; boards without one count issues.
+Without a sprint the active sprint is used, or else the most recently closed one.
+
+  jiractl sprint report
+  jiractl sprint report "PROJ Sprint 42" --format markdown -o retro.md`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSprintReport,
+}
+
+var (
+	sprintReportFormat string
+	sprintReportOutput string
+)
+
+// orderByPattern matches the ORDER BY clause at the end of a JQL query
+var orderByPattern = regexp.MustCompile(`(?is)\s+ORDER\s+BY\s.*$`)
+
+func init() {
+	sprintCmd.AddCommand(sprintReportCmd)
+	sprintReportCmd.Flags().StringVar(&sprintReportFormat, "format", report.FormatText, "Output format: text, markdown or json")
+	sprintReportCmd.Flags().StringVarP(&sprintReportOutput, "output", "o", "", "Output file (default stdout)")
+}
+
+func runSprintReport(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	// Check the format before the output file is created
+	if err := report.CheckFormat(sprintReportFormat); err != nil {
+		return err
+	}
+
+	_, client, board, err := newSprintClient()
+	if err != nil {
+		if err == fuzzyfinder.ErrAbort {
+			return nil
+		}
+		return err
+	}
+
+	var sprint *jira.Sprint
+	if len(args) > 0 {
+		sprint, err = resolveSprint(client, board, args[0])
+	} else {
+		sprint, err = latestSprint(client, board)
+	}
+	if err != nil {
+		return err
+	}
+	if sprint.StartDate == nil {
+		return fmt.Errorf("sprint %s has not started", sprint.Name)
+	}
+
+	reporter, err := newSprintReporter(client, board)
+	if err != nil {
+		return err
+	}
+	r, err := reporter.build(sprint, true)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if sprintReportOutput != "" {
+		f, err := os.Create(sprintReportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	return report.Write(out, r, sprintReportFormat)
+}
+
+// sprintReporter builds reports for the sprints of one board
+type sprintReporter struct {
+	client *jira.Client
+	opts   report.Options
+	search jira.SearchOptions
+	// filterJQL is the board's filter without its ORDER BY clause
+	filterJQL string
+}
+
+// newSprintReporter reads the board's done column and estimation field
+func newSprintReporter(client *jira.Client, board *jira.Board) (*sprintReporter, error) {
+	boardConfig, err := client.GetBoardConfiguration(board.ID)
+	if err != nil {
+		return nil, err
+	}
+	columns := boardConfig.ColumnConfig.Columns
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("board %s has no columns", board.Name)
+	}
+	opts := report.Options{DoneStatuses: make(map[string]bool), Now: time.Now()}
+	for _, status := range columns[len(columns)-1].Status {
+		opts.DoneStatuses[status.ID] = true
+	}
+
+	fields := append([]string(nil), report.Fields...)
+	estimation, err := client.GetBoardEstimation(board.ID)
+	if err != nil {
+		return nil, err
+	}
+	if estimation.Type == "field" && estimation.Field.FieldID != "" {
+		opts.PointsField = estimation.Field.FieldID
+		opts.PointsName = estimation.Field.DisplayName
+		fields = append(fields, opts.PointsField)
+	}
+
+	filter, err := client.GetFilter(boardConfig.Filter.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sprintReporter{
+		client:    client,
+		opts:      opts,
+		search:    jira.SearchOptions{Fields: fields, Expand: []string{"changelog"}},
+		filterJQL: orderByPattern.ReplaceAllString(filter.Jql, ""),
+	}, nil
+}
+
+// build fetches a sprint's issues with their changelogs and computes its report. Issues
+// removed during the sprint are only found with withRemoved, which searches the board's
+// issues updated since the sprint started.
+func (s *sprintReporter) build(sprint *jira.Sprint, withRemoved bool) (*report.Report, error) {
+	current, err := searchAllIssues(s.client, fmt.Sprintf("sprint = %d", sprint.ID), s.search)
+	if err != nil {
+		return nil, err
+	}
+
+	var others []jira.RawIssue
+	if withRemoved && sprint.StartDate != nil {
+		jql := fmt.Sprintf(`(%s) AND (sprint != %d OR sprint IS EMPTY) AND updated >= "%s"`,
+			s.filterJQL, sprint.ID, sprint.StartDate.Local().Format("2006-01-02"))
+		others, err = searchAllIssues(s.client, jql, s.search)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report.Build(*sprint, current, others, s.opts)
+}
+
+// latestSprint returns the board's active sprint, or its most recently closed one
+func latestSprint(client *jira.Client, board *jira.Board) (*jira.Sprint, error) {
+	sprints, err := client.GetSprints(board.ID, jira.SprintActive, jira.SprintClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *jira.Sprint
+	for i, s := range sprints {
+		if s.State == jira.SprintActive {
+			return &sprints[i], nil
+		}
+		if s.CompleteDate != nil && (latest == nil || s.CompleteDate.After(*latest.CompleteDate)) {
+			latest = &sprints[i]
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no active or closed sprints on %s", board.Name)
+	}
+	return latest, nil
+}
+
+// searchAllIssues returns every issue matching jql
+func searchAllIssues(client *jira.Client, jql string, opts jira.SearchOptions) ([]jira.RawIssue, error) {
+	var issues []jira.RawIssue
+	err := client.SearchAll(jql, opts, func(page []jira.RawIssue) error {
+		issues = append(issues, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	return issues, nil
+}
@@ -0,0 +1,462 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	fuzzyfinder "github.com/ktr0731/go-fuzzyfinder"
+	"github.com/spf13/cobra"
+)
+
+// leaveTarget is the --move-to value that leaves unresolved issues in the released version
+const leaveTarget = "none"
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Work with project versions",
+	Long: `Work with the versions (releases) of a project.
+
+The project is taken from --project or the config file. Versions are referenced by
+name or ID.`,
+}
+
+var versionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List versions",
+	Args:  cobra.NoArgs,
+	RunE:  runVersionList,
+}
+
+var versionCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a version",
+	Long: `Create a version, optionally with start and release dates:
+
+  jiractl version create 2.5.0 --start 2026-11-02 --release 2026-11-13`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVersionCreate,
+}
+
+var versionReleaseCmd = &cobra.Command{
+	Use:   "release <version>",
+	Short: "Release a version",
+	Long: `Mark a version as released, today or on --date. Unresolved issues are moved to the
+version given with --move-to (or "none" to leave them); without it you are asked where
+to move them. Jira moves the issues as part of the release, after you confirm (-y skips
+the confirmation).
+
+  jiractl version release 2.4.0 --move-to 2.5.0`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVersionRelease,
+}
+
+var versionArchiveCmd = &cobra.Command{
+	Use:   "archive <version>",
+	Short: "Archive a version",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runVersionArchive,
+}
+
+var versionMoveIssuesCmd = &cobra.Command{
+	Use:   "move-issues <version> <query|jql>",
+	Short: "Add a fix version to the issues matching a saved query or JQL",
+	Long: `Add a fix version to every issue matching a saved query or JQL. With --replace the
+version replaces the issues' other fix versions.
+
+  jiractl version move-issues 2.5.0 "project = PROJ AND sprint in openSprints()"
+  jiractl version move-issues 2.5.0 "fixVersion = 2.4.0 AND resolution = Unresolved" --replace`,
+	Args: cobra.ExactArgs(2),
+	RunE: runVersionMoveIssues,
+}
+
+var (
+	versionProject     string
+	versionAll         bool
+	versionStart       string
+	versionReleaseDate string
+	versionDescription string
+	versionDate        string
+	versionMoveTo      string
+	versionUnarchive   bool
+	versionReplace     bool
+	versionYes         bool
+)
+
+func init() {
+	RootCmd.AddCommand(versionCmd)
+	versionCmd.AddCommand(versionListCmd)
+	versionCmd.AddCommand(versionCreateCmd)
+	versionCmd.AddCommand(versionReleaseCmd)
+	versionCmd.AddCommand(versionArchiveCmd)
+	versionCmd.AddCommand(versionMoveIssuesCmd)
+
+	versionCmd.PersistentFlags().StringVarP(&versionProject, "project", "p", "", "Project key (default from config)")
+	versionListCmd.Flags().BoolVarP(&versionAll, "all", "a", false, "Include archived versions")
+	versionCreateCmd.Flags().StringVar(&versionStart, "start", "", "Start date (YYYY-MM-DD)")
+	versionCreateCmd.Flags().StringVar(&versionReleaseDate, "release", "", "Planned release date (YYYY-MM-DD)")
+	versionCreateCmd.Flags().StringVarP(&versionDescription, "description", "d", "", "Description")
+	versionReleaseCmd.Flags().StringVar(&versionDate, "date", "", "Release date (YYYY-MM-DD, default today)")
+	versionReleaseCmd.Flags().StringVar(&versionMoveTo, "move-to", "", `Version for unresolved issues, or "none"`)
+	versionArchiveCmd.Flags().BoolVar(&versionUnarchive, "undo", false, "Unarchive the version instead")
+	versionMoveIssuesCmd.Flags().BoolVar(&versionReplace, "replace", false, "Replace the issues' other fix versions")
+	versionReleaseCmd.Flags().BoolVarP(&versionYes, "yes", "y", false, "Don't ask for confirmation")
+	versionMoveIssuesCmd.Flags().BoolVarP(&versionYes, "yes", "y", false, "Don't ask for confirmation")
+}
+
+// newVersionClient loads the config and returns a client and the project to work on
+func newVersionClient() (*config.Config, *jira.Client, string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	client, err := jira.NewClient(cfg)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	project := versionProject
+	if project == "" {
+		project = cfg.Project
+	}
+	return cfg, client, strings.ToUpper(project), nil
+}
+
+func runVersionList(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, project, err := newVersionClient()
+	if err != nil {
+		return err
+	}
+
+	versions, err := client.GetVersions(project)
+	if err != nil {
+		return err
+	}
+
+	shown := 0
+	for _, v := range versions {
+		if isArchived(v) && !versionAll {
+			continue
+		}
+		fmt.Println(formatVersionLine(v))
+		shown++
+	}
+	if shown == 0 {
+		fmt.Println("No versions found.")
+	}
+	return nil
+}
+
+func runVersionCreate(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if err := validateDate("--start", versionStart); err != nil {
+		return err
+	}
+	if err := validateDate("--release", versionReleaseDate); err != nil {
+		return err
+	}
+
+	_, client, project, err := newVersionClient()
+	if err != nil {
+		return err
+	}
+
+	v, err := client.CreateVersion(project, args[0], &jira.CreateVersionOptions{
+		Description: versionDescription,
+		StartDate:   versionStart,
+		ReleaseDate: versionReleaseDate,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Version created: %s\n", formatVersionLine(*v))
+	return nil
+}
+
+func runVersionRelease(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	date := versionDate
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	if err := validateDate("--date", date); err != nil {
+		return err
+	}
+
+	_, client, project, err := newVersionClient()
+	if err != nil {
+		return err
+	}
+
+	versions, err := client.GetVersions(project)
+	if err != nil {
+		return err
+	}
+	version, err := findVersion(versions, project, args[0])
+	if err != nil {
+		return err
+	}
+	if isReleased(*version) {
+		return fmt.Errorf("version %s is already released", version.Name)
+	}
+
+	jql := fmt.Sprintf("fixVersion = %s AND resolution = Unresolved", version.ID)
+	unresolved, err := searchAllIssues(client, jql, jira.SearchOptions{Fields: []string{"summary"}})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: %d unresolved issues\n", version.Name, len(unresolved))
+
+	fields := map[string]interface{}{"released": true, "releaseDate": date}
+	label := fmt.Sprintf("Release version %q?", version.Name)
+	var next *gojira.Version
+	if len(unresolved) > 0 {
+		target := versionMoveTo
+		if target == "" {
+			target, err = selectVersionTarget(versions, version)
+			if err != nil {
+				if err == fuzzyfinder.ErrAbort {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+				return err
+			}
+		}
+
+		if strings.EqualFold(target, leaveTarget) {
+			label = fmt.Sprintf("Release version %q and leave %d unresolved issues in it?", version.Name, len(unresolved))
+		} else {
+			next, err = findVersion(versions, project, target)
+			if err != nil {
+				return err
+			}
+			if next.ID == version.ID {
+				return fmt.Errorf("cannot move unresolved issues into the version being released")
+			}
+			// Jira moves the issues as part of the release, so a failure leaves nothing half done
+			fields["moveUnfixedIssuesTo"] = versionSelf(client, next)
+			label = fmt.Sprintf("Release version %q and move %d unresolved issues to %s?", version.Name, len(unresolved), next.Name)
+		}
+	}
+
+	if !versionYes {
+		confirmed, err := promptConfirm(label)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	released, err := client.UpdateVersion(version.ID, fields)
+	if err != nil {
+		return err
+	}
+	if next != nil {
+		fmt.Printf("Moved %d unresolved issues to %s\n", len(unresolved), next.Name)
+	}
+	fmt.Printf("Version released: %s\n", formatVersionLine(*released))
+	return nil
+}
+
+// versionSelf returns the REST URL of a version, which Jira uses to refer to it in
+// fields such as moveUnfixedIssuesTo
+func versionSelf(client *jira.Client, v *gojira.Version) string {
+	if v.Self != "" {
+		return v.Self
+	}
+	base := client.GetBaseURL()
+	return base.JoinPath("rest/api/2/version", v.ID).String()
+}
+
+// selectVersionTarget asks where unresolved issues should go when a version is released.
+// Unreleased versions after it come first, so the next version is at the top.
+func selectVersionTarget(versions []gojira.Version, releasing *gojira.Version) (string, error) {
+	var before, after []gojira.Version
+	seen := false
+	for _, v := range versions {
+		switch {
+		case v.ID == releasing.ID:
+			seen = true
+		case isReleased(v) || isArchived(v):
+		case seen:
+			after = append(after, v)
+		default:
+			before = append(before, v)
+		}
+	}
+
+	var targets, items []string
+	for _, v := range append(after, before...) {
+		targets = append(targets, v.ID)
+		items = append(items, formatVersionLine(v))
+	}
+	targets = append(targets, leaveTarget)
+	items = append(items, "Leave them in "+releasing.Name)
+
+	idx, err := fzfSelect(items, "Move unresolved issues to")
+	if err != nil {
+		return "", err
+	}
+	return targets[idx], nil
+}
+
+func runVersionArchive(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	_, client, project, err := newVersionClient()
+	if err != nil {
+		return err
+	}
+
+	version, err := resolveVersion(client, project, args[0])
+	if err != nil {
+		return err
+	}
+
+	updated, err := client.UpdateVersion(version.ID, map[string]interface{}{"archived": !versionUnarchive})
+	if err != nil {
+		return err
+	}
+	if versionUnarchive {
+		fmt.Printf("Version unarchived: %s\n", updated.Name)
+	} else {
+		fmt.Printf("Version archived: %s\n", updated.Name)
+	}
+	return nil
+}
+
+func runVersionMoveIssues(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, client, project, err := newVersionClient()
+	if err != nil {
+		return err
+	}
+
+	version, err := resolveVersion(client, project, args[0])
+	if err != nil {
+		return err
+	}
+
+	query := cfg.GetQuery(args[1])
+	if query == nil {
+		query = &config.Query{Name: "jql", JQL: args[1]}
+	}
+	jql, err := expandQuery(cfg, client, query, nil)
+	if err != nil {
+		if err == ErrPromptCancelled {
+			fmt.Println("\nCancelled.")
+			return nil
+		}
+		return err
+	}
+
+	issues, err := searchAllIssues(client, jql, jira.SearchOptions{Fields: []string{"summary", "fixVersions"}})
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Println("No issues found.")
+		return nil
+	}
+
+	if !versionYes {
+		confirmed, err := promptConfirm(fmt.Sprintf("Set fix version %s on %d issues?", version.Name, len(issues)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	for _, issue := range issues {
+		if versionReplace {
+			err = client.UpdateIssueFields(issue.Key, map[string]interface{}{
+				"fixVersions": []map[string]string{{"id": version.ID}},
+			})
+		} else {
+			err = client.ChangeFixVersions(issue.Key, []string{version.ID}, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Set fix version %s on %d issues\n", version.Name, len(issues))
+	return nil
+}
+
+// resolveVersion finds a project version by name or ID
+func resolveVersion(client *jira.Client, project, arg string) (*gojira.Version, error) {
+	versions, err := client.GetVersions(project)
+	if err != nil {
+		return nil, err
+	}
+	return findVersion(versions, project, arg)
+}
+
+// findVersion returns the version with the given name or ID
+func findVersion(versions []gojira.Version, project, arg string) (*gojira.Version, error) {
+	for i, v := range versions {
+		if v.ID == arg || strings.EqualFold(v.Name, arg) {
+			return &versions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("version not found in %s: %s", project, arg)
+}
+
+// formatVersionLine shows a version's ID, state, name and dates on one line
+func formatVersionLine(v gojira.Version) string {
+	state := "unreleased"
+	switch {
+	case isArchived(v):
+		state = "archived"
+	case isReleased(v):
+		state = "released"
+	}
+	dates := ""
+	if v.StartDate != "" || v.ReleaseDate != "" {
+		dates = fmt.Sprintf("  %s - %s", formatVersionDate(v.StartDate), formatVersionDate(v.ReleaseDate))
+	}
+	return fmt.Sprintf("%-6s %-10s %s%s", v.ID, state, v.Name, dates)
+}
+
+// formatVersionDate shows a missing start or release date as "?"
+func formatVersionDate(date string) string {
+	if date == "" {
+		return "?"
+	}
+	return date
+}
+
+// isReleased and isArchived read the optional flags of a version
+func isReleased(v gojira.Version) bool {
+	return v.Released != nil && *v.Released
+}
+
+func isArchived(v gojira.Version) bool {
+	return v.Archived != nil && *v.Archived
+}
+
+// validateDate checks that a date flag, if set, is YYYY-MM-DD
+func validateDate(flag, date string) error {
+	if date == "" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return fmt.Errorf("invalid %s date %q, expected YYYY-MM-DD", flag, date)
+	}
+	return nil
+}
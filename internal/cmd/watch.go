@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/config"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+	"github.com/eugenetaranov/jiractl/internal/watch"
+	"golang.org/x/term"
+)
+
+// minWatchInterval keeps watch mode from hammering the server
+const minWatchInterval = 10 * time.Second
+
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+// watchEvent is the JSON passed to --exec commands on stdin
+type watchEvent struct {
+	Query string `json:"query"`
+	URL   string `json:"url"`
+	watch.Change
+}
+
+// watchQuery polls a query until interrupted, printing issues that entered, left,
+// or changed status or assignee since the previous poll
+func watchQuery(cfg *config.Config, client *jira.Client, query *config.Query, params map[string]string, interval time.Duration, execCmd string) error {
+	if interval < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+	}
+
+	jql, err := expandQuery(cfg, client, query, params)
+	if err != nil {
+		if err == ErrPromptCancelled {
+			fmt.Println("\nCancelled.")
+			return nil
+		}
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Watching query: %s (every %s, Ctrl+C to stop)\n", query.Name, interval)
+	fmt.Printf("JQL: %s\n\n", jql)
+
+	color := useColor()
+	var prev watch.Snapshot
+	for {
+		cur, err := fetchSnapshot(client, jql, query.Limit)
+		if err != nil {
+			fmt.Printf("%s  %v\n", time.Now().Format("15:04:05"), err)
+		} else {
+			if prev == nil {
+				printSnapshot(cur)
+			} else {
+				for _, change := range watch.Diff(prev, cur) {
+					printChange(change, color)
+					if execCmd != "" {
+						event := watchEvent{Query: query.Name, URL: issueURL(cfg.Server, change.Issue.Key), Change: change}
+						if err := runWatchHook(ctx, execCmd, event); err != nil {
+							fmt.Printf("Warning: --exec failed for %s: %v\n", change.Issue.Key, err)
+						}
+					}
+				}
+			}
+			prev = cur
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// fetchSnapshot runs a search and returns the state of every matching issue
+func fetchSnapshot(client *jira.Client, jql string, limit int) (watch.Snapshot, error) {
+	var issues []jira.RawIssue
+	err := client.SearchAll(jql, jira.SearchOptions{Fields: watch.Fields, Limit: limit}, func(page []jira.RawIssue) error {
+		issues = append(issues, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	return watch.NewSnapshot(issues), nil
+}
+
+func printSnapshot(s watch.Snapshot) {
+	fmt.Printf("%s  %d issues\n", time.Now().Format("15:04:05"), len(s))
+	for _, change := range watch.Diff(nil, s) {
+		fmt.Printf("  %s\n", formatIssueState(change.Issue))
+	}
+}
+
+func printChange(change watch.Change, color bool) {
+	marker, code := "~", colorYellow
+	detail := formatIssueState(change.Issue)
+	switch change.Type {
+	case watch.Entered:
+		marker, code = "+", colorGreen
+	case watch.Left:
+		marker, code = "-", colorRed
+	case watch.Changed:
+		detail = change.Issue.Key + "  " + describeChange(change)
+	}
+
+	line := fmt.Sprintf("%s %s", marker, detail)
+	if color {
+		line = code + line + colorReset
+	}
+	fmt.Printf("%s  %s\n", time.Now().Format("15:04:05"), line)
+}
+
+// describeChange summarises what changed on an issue, e.g. "status: Open -> Done"
+func describeChange(change watch.Change) string {
+	prev, cur := change.Previous, change.Issue
+	desc := ""
+	if prev.Status != cur.Status {
+		desc = fmt.Sprintf("status: %s -> %s", orNone(prev.Status), orNone(cur.Status))
+	}
+	if prev.Assignee != cur.Assignee {
+		if desc != "" {
+			desc += ", "
+		}
+		desc += fmt.Sprintf("assignee: %s -> %s", orNone(prev.Assignee), orNone(cur.Assignee))
+	}
+	return desc + "  " + cur.Summary
+}
+
+func formatIssueState(s watch.IssueState) string {
+	return fmt.Sprintf("%s  [%s]  %s  %s", s.Key, orNone(s.Status), orNone(s.Assignee), s.Summary)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func issueURL(server, key string) string {
+	return fmt.Sprintf("%s/browse/%s", server, key)
+}
+
+// useColor reports whether output goes to a terminal that wants colours
+func useColor() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// runWatchHook runs a shell command with the event as JSON on stdin
+func runWatchHook(ctx context.Context, command string, event watchEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	c.Stdin = bytes.NewReader(append(data, '\n'))
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(),
+		"JIRACTL_QUERY="+event.Query,
+		"JIRACTL_CHANGE="+event.Type,
+		"JIRACTL_ISSUE_KEY="+event.Issue.Key,
+	)
+	return c.Run()
+}
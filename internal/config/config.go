@@ -4,9 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/eugenetaranov/jiractl/internal/git"
 )
 
 const (
@@ -19,12 +20,63 @@ type IssueDefaults struct {
 	EpicLink  string   `toml:"epic_link,omitempty"`
 	IssueType string   `toml:"issue_type,omitempty"`
 	Labels    []string `toml:"labels,omitempty"`
+	// EpicLinkField overrides epic link field discovery, e.g. "parent" or "customfield_10014"
+	EpicLinkField string `toml:"epic_link_field,omitempty"`
 }
 
 type Query struct {
 	Name  string `toml:"name"`
 	JQL   string `toml:"jql"`
-	Limit int    `toml:"limit,omitempty"`
+	Limit int    `toml:"limit,omitzero"`
+	// Fields lists extra fields to fetch, by name or ID, in addition to those shown as columns
+	Fields []string `toml:"fields,omitempty"`
+	// Columns lists the fields shown for each issue in the picker, by name or ID.
+	// "key" is the issue key. Defaults to key, status and summary.
+	Columns []string `toml:"columns,omitempty"`
+	// FilterID links the query to a server-side filter it was imported from or published to
+	FilterID string `toml:"filter_id,omitempty"`
+	// FilterJQL is the filter's JQL when the query was last imported, published or synced,
+	// so sync can tell changes on the server from the query's own placeholders and edits
+	FilterJQL string `toml:"filter_jql,omitempty"`
+}
+
+// Notify configures desktop notifications for new issues in saved queries
+type Notify struct {
+	// Queries are the saved queries checked when none are given on the command line
+	Queries []string `toml:"queries,omitempty"`
+	// Command is run for each notification instead of sending it over D-Bus
+	Command string `toml:"command,omitempty"`
+	// Interval is the time between checks when running as a daemon, e.g. "5m"
+	Interval string `toml:"interval,omitempty"`
+	// RateLimit is the minimum time between notifications for the same query, e.g. "15m"
+	RateLimit string `toml:"rate_limit,omitempty"`
+}
+
+// Metrics maps workflow statuses to the stages used by the metrics command. Statuses
+// default to their Jira status category: "In Progress" for started, "Done" for finished.
+type Metrics struct {
+	// InProgress lists the status names that mark work as started
+	InProgress []string `toml:"in_progress,omitempty"`
+	// Done lists the status names that mark work as finished
+	Done []string `toml:"done,omitempty"`
+}
+
+// Git configures how issue keys are found in git branch names and how branches are created
+type Git struct {
+	// KeyPattern is a regular expression matching the issue key in a branch name. If it
+	// has a capture group, the first group is the key. Defaults to git.DefaultKeyPattern.
+	// Commit messages are always searched for keys with git.DefaultKeyPattern.
+	KeyPattern string `toml:"key_pattern,omitempty"`
+	// BranchTemplate is the Go template for new branch names. Defaults to git.DefaultBranchTemplate.
+	BranchTemplate string `toml:"branch_template,omitempty"`
+	// StartStatus is the status an issue is moved to when work starts, e.g. "In Progress"
+	StartStatus string `toml:"start_status,omitempty"`
+	// Start and Assign make creating a branch start the issue and assign it to you
+	Start  bool `toml:"start,omitempty"`
+	Assign bool `toml:"assign,omitempty"`
+	// BranchAction offers "Create git branch" next to "Show details" after an issue is
+	// picked from query results inside a git repository
+	BranchAction bool `toml:"branch_action,omitempty"`
 }
 
 type Config struct {
@@ -32,6 +84,16 @@ type Config struct {
 	Project       string        `toml:"project"`
 	IssueDefaults IssueDefaults `toml:"issue_defaults,omitempty"`
 	Queries       []Query       `toml:"queries,omitempty"`
+	Templates     []Template    `toml:"templates,omitempty"`
+	Notify        Notify        `toml:"notify,omitempty"`
+	Metrics       Metrics       `toml:"metrics,omitempty"`
+	Git           Git           `toml:"git,omitempty"`
+
+	// Board is the default agile board for board and sprint commands, by ID or name
+	Board string `toml:"board,omitempty"`
+
+	// dirTemplates are loaded from TemplatesDir and never written back to the config file
+	dirTemplates []Template
 }
 
 func ConfigPath() (string, error) {
@@ -49,12 +111,17 @@ func Load() (*Config, error) {
 	}
 
 	cfg := &Config{}
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return cfg, nil
+	if _, err := os.Stat(path); err == nil {
+		if _, err := toml.DecodeFile(path, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if _, err := toml.DecodeFile(path, cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	cfg.dirTemplates, err = loadTemplateDir()
+	if err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
@@ -80,9 +147,57 @@ func (c *Config) Save() error {
 	return nil
 }
 
-// ExpandJQL replaces ${project} placeholder with the actual project key
-func (c *Config) ExpandJQL(jql string) string {
-	return strings.ReplaceAll(jql, "${project}", c.Project)
+// BuiltinVariables lists the JQL variables jiractl fills in itself
+var BuiltinVariables = []string{"project", "me", "today", "git_branch_key"}
+
+// builtinValue returns the value of a built-in JQL variable. ok is false for
+// unknown names and for built-ins that cannot be determined right now, such as
+// ${git_branch_key} outside a git branch, so they can be supplied like parameters.
+func (c *Config) builtinValue(name string) (value string, ok bool) {
+	switch name {
+	case "project":
+		return c.Project, true
+	case "me":
+		return "currentUser()", true
+	case "today":
+		return time.Now().Format("2006-01-02"), true
+	case "git_branch_key":
+		key, err := git.BranchIssueKey(c.Git.KeyPattern)
+		if err != nil {
+			return "", false
+		}
+		return key, true
+	}
+	return "", false
+}
+
+// QueryParams returns the ${name} and ${name:default} parameters in jql that are
+// not provided by built-in variables
+func (c *Config) QueryParams(jql string) []Placeholder {
+	var params []Placeholder
+	for _, p := range Placeholders(jql) {
+		if _, ok := c.builtinValue(p.Name); ok {
+			continue
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+// ExpandJQL replaces built-in variables such as ${project} and ${today}, and
+// ${name} parameters with values from params, falling back to their defaults.
+// Values in params take precedence over built-in variables.
+func (c *Config) ExpandJQL(jql string, params map[string]string) string {
+	values := map[string]string{}
+	for _, p := range Placeholders(jql) {
+		if v, ok := c.builtinValue(p.Name); ok {
+			values[p.Name] = v
+		}
+	}
+	for name, v := range params {
+		values[name] = v
+	}
+	return ExpandPlaceholders(jql, values)
 }
 
 // GetQuery returns a query by name
@@ -95,6 +210,16 @@ func (c *Config) GetQuery(name string) *Query {
 	return nil
 }
 
+// GetQueryByFilter returns the query linked to a server-side filter
+func (c *Config) GetQueryByFilter(filterID string) *Query {
+	for i := range c.Queries {
+		if c.Queries[i].FilterID == filterID {
+			return &c.Queries[i]
+		}
+	}
+	return nil
+}
+
 // QueryNames returns a list of all query names
 func (c *Config) QueryNames() []string {
 	names := make([]string, len(c.Queries))
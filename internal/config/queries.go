@@ -0,0 +1,352 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// The query management methods below edit the [[queries]] blocks of the config
+// file in place instead of re-encoding it with Save, so comments, ordering and
+// formatting elsewhere in the file are preserved.
+
+var (
+	tableHeaderRe = regexp.MustCompile(`^\s*\[`)
+	queryHeaderRe = regexp.MustCompile(`^\s*\[\[\s*queries\s*\]\]`)
+	queryNameRe   = regexp.MustCompile(`^(\s*name\s*=\s*)("(?:[^"\\]|\\.)*"|'[^']*')(.*)$`)
+)
+
+// ReservedQueryNames are the names and aliases of the query subcommands. A saved query
+// with one of these names could not be run as `jiractl query <name>`.
+var ReservedQueryNames = []string{"add", "edit", "rm", "remove", "delete", "rename", "list", "show"}
+
+// CheckQueryName returns an error if name is reserved for a query subcommand
+func CheckQueryName(name string) error {
+	for _, reserved := range ReservedQueryNames {
+		if name == reserved {
+			return fmt.Errorf("query name %q is reserved for the 'jiractl query %s' command", name, reserved)
+		}
+	}
+	return nil
+}
+
+// ReservedQueries returns the names of saved queries hidden by a query subcommand
+func (c *Config) ReservedQueries() []string {
+	var names []string
+	for _, q := range c.Queries {
+		if CheckQueryName(q.Name) != nil {
+			names = append(names, q.Name)
+		}
+	}
+	return names
+}
+
+// queryBlock is the line range of one [[queries]] entry in the config file
+type queryBlock struct {
+	// start includes comment lines directly above the header
+	start  int
+	header int
+	end    int
+	name   string
+}
+
+// AddQuery appends a new saved query
+func (c *Config) AddQuery(q Query) error {
+	if err := CheckQueryName(q.Name); err != nil {
+		return err
+	}
+	if c.GetQuery(q.Name) != nil {
+		return fmt.Errorf("query already exists: %s", q.Name)
+	}
+
+	block, err := renderQuery(q)
+	if err != nil {
+		return err
+	}
+
+	err = editConfigFile(func(lines []string) ([]string, error) {
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		return append(lines, block...), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Queries = append(c.Queries, q)
+	return nil
+}
+
+// UpdateQuery replaces the saved query called name, keeping the comments above it
+func (c *Config) UpdateQuery(name string, q Query) error {
+	existing := c.GetQuery(name)
+	if existing == nil {
+		return fmt.Errorf("query not found: %s", name)
+	}
+	if q.Name != name {
+		if err := CheckQueryName(q.Name); err != nil {
+			return err
+		}
+		if c.GetQuery(q.Name) != nil {
+			return fmt.Errorf("query already exists: %s", q.Name)
+		}
+	}
+
+	block, err := renderQuery(q)
+	if err != nil {
+		return err
+	}
+
+	err = editQueryBlock(name, func(lines []string, b queryBlock) []string {
+		out := append([]string{}, lines[:b.header]...)
+		out = append(out, block...)
+		return append(out, lines[b.end:]...)
+	})
+	if err != nil {
+		return err
+	}
+
+	*existing = q
+	return nil
+}
+
+// RenameQuery changes the name of a saved query, leaving the rest of its entry untouched
+func (c *Config) RenameQuery(oldName, newName string) error {
+	existing := c.GetQuery(oldName)
+	if existing == nil {
+		return fmt.Errorf("query not found: %s", oldName)
+	}
+	if err := CheckQueryName(newName); err != nil {
+		return err
+	}
+	if c.GetQuery(newName) != nil {
+		return fmt.Errorf("query already exists: %s", newName)
+	}
+
+	quoted, err := tomlString(newName)
+	if err != nil {
+		return err
+	}
+
+	renamed := false
+	err = editQueryBlock(oldName, func(lines []string, b queryBlock) []string {
+		for i := b.header + 1; i < b.end; i++ {
+			if m := queryNameRe.FindStringSubmatch(lines[i]); m != nil {
+				lines[i] = m[1] + quoted + m[3]
+				renamed = true
+				break
+			}
+		}
+		return lines
+	})
+	if err != nil {
+		return err
+	}
+	if !renamed {
+		return fmt.Errorf("could not find the name of query %q in the config file", oldName)
+	}
+
+	existing.Name = newName
+	return nil
+}
+
+// RemoveQuery deletes a saved query along with the comments directly above it
+func (c *Config) RemoveQuery(name string) error {
+	if c.GetQuery(name) == nil {
+		return fmt.Errorf("query not found: %s", name)
+	}
+
+	err := editQueryBlock(name, func(lines []string, b queryBlock) []string {
+		end := b.end
+		// Drop the blank line separating this entry from the next one
+		if b.start > 0 && strings.TrimSpace(lines[b.start-1]) == "" {
+			b.start--
+		}
+		return append(lines[:b.start:b.start], lines[end:]...)
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range c.Queries {
+		if c.Queries[i].Name == name {
+			c.Queries = append(c.Queries[:i], c.Queries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// RenderQuery returns the TOML for a single [[queries]] entry
+func RenderQuery(q Query) (string, error) {
+	lines, err := renderQuery(q)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// ParseQuery decodes a single [[queries]] entry as produced by RenderQuery
+func ParseQuery(data string) (*Query, error) {
+	var doc struct {
+		Queries []Query `toml:"queries"`
+	}
+	if _, err := toml.Decode(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+	if len(doc.Queries) != 1 {
+		return nil, fmt.Errorf("expected exactly one [[queries]] entry, found %d", len(doc.Queries))
+	}
+	return &doc.Queries[0], nil
+}
+
+func renderQuery(q Query) ([]string, error) {
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	doc := struct {
+		Queries []Query `toml:"queries"`
+	}{Queries: []Query{q}}
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n"), nil
+}
+
+func tomlString(s string) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]string{"v": s}); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(buf.String()), "v =")), nil
+}
+
+// editQueryBlock applies fn to the config file lines, passing the block of the named query
+func editQueryBlock(name string, fn func(lines []string, b queryBlock) []string) error {
+	return editConfigFile(func(lines []string) ([]string, error) {
+		blocks, err := findQueryBlocks(lines)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range blocks {
+			if b.name == name {
+				return fn(lines, b), nil
+			}
+		}
+		return nil, fmt.Errorf("query %q not found in the config file", name)
+	})
+}
+
+// findQueryBlocks locates every [[queries]] entry. An entry runs from its header to the
+// next table header; comments directly above a header belong to the entry below them.
+func findQueryBlocks(lines []string) ([]queryBlock, error) {
+	var headers []int
+	inMultiline := false
+	for i, line := range lines {
+		if !inMultiline && tableHeaderRe.MatchString(line) {
+			headers = append(headers, i)
+		}
+		if (strings.Count(line, `"""`)+strings.Count(line, `'''`))%2 == 1 {
+			inMultiline = !inMultiline
+		}
+	}
+
+	var blocks []queryBlock
+	for n, h := range headers {
+		if !queryHeaderRe.MatchString(lines[h]) {
+			continue
+		}
+
+		end := len(lines)
+		if n+1 < len(headers) {
+			end = headers[n+1]
+		}
+		for end > h+1 && isCommentOrBlank(lines[end-1]) {
+			end--
+		}
+
+		start := h
+		for start > 0 && isComment(lines[start-1]) {
+			start--
+		}
+
+		q, err := ParseQuery(strings.Join(lines[h:end], "\n"))
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, queryBlock{start: start, header: h, end: end, name: q.Name})
+	}
+	return blocks, nil
+}
+
+func isComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "#")
+}
+
+func isCommentOrBlank(line string) bool {
+	return strings.TrimSpace(line) == "" || isComment(line)
+}
+
+// editConfigFile rewrites the config file with the lines returned by fn
+func editConfigFile(fn func(lines []string) ([]string, error)) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	mode := os.FileMode(0o600)
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+	case os.IsNotExist(err):
+	default:
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	lines, err = fn(lines)
+	if err != nil {
+		return err
+	}
+
+	content := strings.Join(lines, "\n") + "\n"
+
+	// Make sure the result still parses before replacing the file
+	var check Config
+	if _, err := toml.Decode(content, &check); err != nil {
+		return fmt.Errorf("refusing to write invalid config file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".jiractl-*.toml")
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
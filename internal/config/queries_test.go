@@ -0,0 +1,224 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfig = `# jiractl config
+server = "https://acme.atlassian.net"
+project = "PROJ"
+
+# Bugs I care about
+[[queries]]
+name = "Bugs"
+jql = "type = Bug"
+limit = 20 # keep it short
+
+[[queries]]
+name = 'Release'
+jql = """
+fixVersion = 2.4
+[not a table]
+"""
+
+# Templates below
+[[templates]]
+name = "bug"
+`
+
+// loadTestConfig writes content as the config file in a fresh home directory and loads it
+func loadTestConfig(t *testing.T, content string) *Config {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	if err := os.WriteFile(filepath.Join(home, ConfigFileName), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return cfg
+}
+
+func readTestConfig(t *testing.T) string {
+	t.Helper()
+	path, err := ConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestQueryEdits(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*Config) error
+		want string
+	}{
+		{
+			name: "add",
+			edit: func(c *Config) error {
+				return c.AddQuery(Query{Name: "Mine", JQL: "assignee = currentUser()", Limit: 10})
+			},
+			want: testConfig + `
+[[queries]]
+name = "Mine"
+jql = "assignee = currentUser()"
+limit = 10
+`,
+		},
+		{
+			name: "update keeps the comment above",
+			edit: func(c *Config) error {
+				return c.UpdateQuery("Bugs", Query{Name: "Bugs", JQL: "type = Bug AND priority = High"})
+			},
+			want: strings.Replace(testConfig, `name = "Bugs"
+jql = "type = Bug"
+limit = 20 # keep it short
+`, `name = "Bugs"
+jql = "type = Bug AND priority = High"
+`, 1),
+		},
+		{
+			name: "update the last query before another table",
+			edit: func(c *Config) error {
+				return c.UpdateQuery("Release", Query{Name: "Release", JQL: "fixVersion = 2.5"})
+			},
+			want: strings.Replace(testConfig, `name = 'Release'
+jql = """
+fixVersion = 2.4
+[not a table]
+"""
+`, `name = "Release"
+jql = "fixVersion = 2.5"
+`, 1),
+		},
+		{
+			name: "rename keeps the rest of the entry",
+			edit: func(c *Config) error {
+				return c.RenameQuery("Bugs", "Crashes")
+			},
+			want: strings.Replace(testConfig, `name = "Bugs"`, `name = "Crashes"`, 1),
+		},
+		{
+			name: "rename a single-quoted name",
+			edit: func(c *Config) error {
+				return c.RenameQuery("Release", `Release "2.4"`)
+			},
+			want: strings.Replace(testConfig, `name = 'Release'`, `name = "Release \"2.4\""`, 1),
+		},
+		{
+			name: "remove drops the comment and blank line",
+			edit: func(c *Config) error {
+				return c.RemoveQuery("Bugs")
+			},
+			want: strings.Replace(testConfig, `
+# Bugs I care about
+[[queries]]
+name = "Bugs"
+jql = "type = Bug"
+limit = 20 # keep it short
+`, "", 1),
+		},
+		{
+			name: "remove the query with a multi-line string",
+			edit: func(c *Config) error {
+				return c.RemoveQuery("Release")
+			},
+			want: strings.Replace(testConfig, `
+[[queries]]
+name = 'Release'
+jql = """
+fixVersion = 2.4
+[not a table]
+"""
+`, "", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadTestConfig(t, testConfig)
+			if err := tt.edit(cfg); err != nil {
+				t.Fatalf("edit error = %v", err)
+			}
+			if got := readTestConfig(t); got != tt.want {
+				t.Errorf("config file =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			// The in-memory queries match the file
+			reloaded, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got, want := strings.Join(cfg.QueryNames(), ","), strings.Join(reloaded.QueryNames(), ","); got != want {
+				t.Errorf("queries in memory = %s, in the file = %s", got, want)
+			}
+		})
+	}
+}
+
+func TestQueryEditErrors(t *testing.T) {
+	cfg := loadTestConfig(t, testConfig)
+	for name, err := range map[string]error{
+		"add existing":       cfg.AddQuery(Query{Name: "Bugs", JQL: "x"}),
+		"update missing":     cfg.UpdateQuery("Nope", Query{Name: "Nope", JQL: "x"}),
+		"update to existing": cfg.UpdateQuery("Bugs", Query{Name: "Release", JQL: "x"}),
+		"rename to existing": cfg.RenameQuery("Bugs", "Release"),
+		"add reserved":       cfg.AddQuery(Query{Name: "list", JQL: "x"}),
+		"update to reserved": cfg.UpdateQuery("Bugs", Query{Name: "show", JQL: "x"}),
+		"rename to reserved": cfg.RenameQuery("Bugs", "rm"),
+		"remove missing":     cfg.RemoveQuery("Nope"),
+	} {
+		if err == nil {
+			t.Errorf("%s: error = nil, want error", name)
+		}
+	}
+	if got := readTestConfig(t); got != testConfig {
+		t.Errorf("config file changed after failed edits:\n%s", got)
+	}
+}
+
+func TestAddQueryCreatesFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	cfg := &Config{}
+	if err := cfg.AddQuery(Query{Name: "Mine", JQL: "assignee = currentUser()"}); err != nil {
+		t.Fatalf("AddQuery() error = %v", err)
+	}
+	want := `[[queries]]
+name = "Mine"
+jql = "assignee = currentUser()"
+`
+	if got := readTestConfig(t); got != want {
+		t.Errorf("config file =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReservedQueries(t *testing.T) {
+	cfg := loadTestConfig(t, testConfig+`
+[[queries]]
+name = "list"
+jql = "type = Task"
+`)
+	if got := strings.Join(cfg.ReservedQueries(), ","); got != "list" {
+		t.Errorf("ReservedQueries() = %s, want list", got)
+	}
+	// A query hidden by a subcommand can still be renamed
+	if err := cfg.RenameQuery("list", "Tasks"); err != nil {
+		t.Fatalf("RenameQuery() error = %v", err)
+	}
+	if got := cfg.ReservedQueries(); len(got) != 0 {
+		t.Errorf("ReservedQueries() after rename = %v, want none", got)
+	}
+}
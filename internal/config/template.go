@@ -0,0 +1,201 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Template is a named issue template used by `jiractl create --template`.
+// Text values may contain ${name} or ${name:default} placeholders which are
+// prompted for at creation time.
+type Template struct {
+	Name          string            `toml:"name"`
+	IssueType     string            `toml:"issue_type,omitempty"`
+	SummaryPrefix string            `toml:"summary_prefix,omitempty"`
+	Description   string            `toml:"description,omitempty"`
+	Labels        []string          `toml:"labels,omitempty"`
+	Components    []string          `toml:"components,omitempty"`
+	Fields        map[string]string `toml:"fields,omitempty"`
+}
+
+// Placeholder is a ${name} or ${name:default} reference in a text value
+type Placeholder struct {
+	Name       string
+	Default    string
+	HasDefault bool
+}
+
+var placeholderRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::([^}]*))?\}`)
+
+// Placeholders returns the placeholders used in the given strings, in order of first appearance
+func Placeholders(texts ...string) []Placeholder {
+	var result []Placeholder
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, m := range placeholderRe.FindAllStringSubmatchIndex(text, -1) {
+			name := text[m[2]:m[3]]
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			p := Placeholder{Name: name}
+			if m[4] >= 0 {
+				p.Default = text[m[4]:m[5]]
+				p.HasDefault = true
+			}
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// ExpandPlaceholders replaces ${name} and ${name:default} with values[name], falling back
+// to the default. Placeholders without a value or default are left untouched.
+func ExpandPlaceholders(text string, values map[string]string) string {
+	return placeholderRe.ReplaceAllStringFunc(text, func(m string) string {
+		sub := placeholderRe.FindStringSubmatch(m)
+		if v, ok := values[sub[1]]; ok {
+			return v
+		}
+		if strings.Contains(m, ":") {
+			return sub[2]
+		}
+		return m
+	})
+}
+
+// Placeholders returns all placeholders used by the template
+func (t *Template) Placeholders() []Placeholder {
+	texts := []string{t.SummaryPrefix, t.Description}
+	texts = append(texts, t.Labels...)
+	texts = append(texts, t.Components...)
+
+	// Sort field names so prompts come in a stable order
+	names := make([]string, 0, len(t.Fields))
+	for name := range t.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		texts = append(texts, t.Fields[name])
+	}
+	return Placeholders(texts...)
+}
+
+// Expand returns a copy of the template with all placeholders substituted
+func (t *Template) Expand(values map[string]string) *Template {
+	expanded := &Template{
+		Name:          t.Name,
+		IssueType:     t.IssueType,
+		SummaryPrefix: ExpandPlaceholders(t.SummaryPrefix, values),
+		Description:   ExpandPlaceholders(t.Description, values),
+	}
+	for _, l := range t.Labels {
+		expanded.Labels = append(expanded.Labels, ExpandPlaceholders(l, values))
+	}
+	for _, c := range t.Components {
+		expanded.Components = append(expanded.Components, ExpandPlaceholders(c, values))
+	}
+	if len(t.Fields) > 0 {
+		expanded.Fields = make(map[string]string, len(t.Fields))
+		for name, v := range t.Fields {
+			expanded.Fields[name] = ExpandPlaceholders(v, values)
+		}
+	}
+	return expanded
+}
+
+// TemplatesDir returns the directory holding one TOML file per template
+func TemplatesDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(dir, "jiractl", "templates"), nil
+}
+
+// ReleaseNotesDir returns the directory holding user templates for release notes
+func ReleaseNotesDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(dir, "jiractl", "release-notes"), nil
+}
+
+// loadTemplateDir reads templates from TemplatesDir. A template's name defaults to its file name.
+func loadTemplateDir() ([]Template, error) {
+	dir, err := TemplatesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	sort.Strings(paths)
+
+	templates := make([]Template, 0, len(paths))
+	for _, path := range paths {
+		var t Template
+		if _, err := toml.DecodeFile(path, &t); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", filepath.Base(path), err)
+		}
+		if t.Name == "" {
+			t.Name = strings.TrimSuffix(filepath.Base(path), ".toml")
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+// AllTemplates returns templates from the config file followed by those from TemplatesDir.
+// Templates in the config file take precedence when names clash.
+func (c *Config) AllTemplates() []Template {
+	all := append([]Template{}, c.Templates...)
+	for _, t := range c.dirTemplates {
+		if c.findTemplate(t.Name) == nil {
+			all = append(all, t)
+		}
+	}
+	return all
+}
+
+// GetTemplate returns a template by name
+func (c *Config) GetTemplate(name string) *Template {
+	if t := c.findTemplate(name); t != nil {
+		return t
+	}
+	for i := range c.dirTemplates {
+		if c.dirTemplates[i].Name == name {
+			return &c.dirTemplates[i]
+		}
+	}
+	return nil
+}
+
+// TemplateNames returns a list of all template names
+func (c *Config) TemplateNames() []string {
+	all := c.AllTemplates()
+	names := make([]string, len(all))
+	for i, t := range all {
+		names[i] = t.Name
+	}
+	return names
+}
+
+func (c *Config) findTemplate(name string) *Template {
+	for i := range c.Templates {
+		if c.Templates[i].Name == name {
+			return &c.Templates[i]
+		}
+	}
+	return nil
+}
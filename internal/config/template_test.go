@@ -0,0 +1,47 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandPlaceholders(t *testing.T) {
+	values := map[string]string{"component": "api", "env": "", "version": "2.4"}
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "no placeholders", text: "Plain text", want: "Plain text"},
+		{name: "value", text: "Bug in ${component}", want: "Bug in api"},
+		{name: "value wins over default", text: "${component:web}", want: "api"},
+		{name: "empty value", text: "[${env:prod}]", want: "[]"},
+		{name: "default", text: "Browser: ${browser:Chrome}", want: "Browser: Chrome"},
+		{name: "empty default", text: "[${browser:}]", want: "[]"},
+		{name: "default with spaces and colons", text: "${steps:1. Open: the page}", want: "1. Open: the page"},
+		{name: "missing value is left alone", text: "Seen in ${browser}", want: "Seen in ${browser}"},
+		{name: "repeated", text: "${version} and ${version}", want: "2.4 and 2.4"},
+		{name: "several", text: "${component}/${browser:firefox}/${os}", want: "api/firefox/${os}"},
+		{name: "not a placeholder", text: "$component {component} ${1st}", want: "$component {component} ${1st}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandPlaceholders(tt.text, values); got != tt.want {
+				t.Errorf("ExpandPlaceholders(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	got := Placeholders("${a} ${b:x}", "${a:ignored} ${c:}")
+	want := []Placeholder{
+		{Name: "a"},
+		{Name: "b", Default: "x", HasDefault: true},
+		{Name: "c", HasDefault: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Placeholders() = %+v, want %+v", got, want)
+	}
+}
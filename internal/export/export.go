@@ -0,0 +1,178 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+	// FormatExcel is CSV tuned for spreadsheet apps: UTF-8 BOM, CRLF line
+	// endings, escaped formulas and cells capped at Excel's size limit
+	FormatExcel = "excel"
+
+	// KeyColumn and ChangelogColumn are pseudo field IDs for the issue key and history
+	KeyColumn       = "key"
+	ChangelogColumn = "changelog"
+
+	excelCellLimit = 32767
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatJSONL, FormatCSV, FormatExcel}
+
+// Column is an output column backed by an issue field
+type Column struct {
+	// ID is the field ID, or KeyColumn / ChangelogColumn
+	ID string
+	// Name is the CSV header and JSON key
+	Name string
+}
+
+// Writer writes issues with a fixed column order
+type Writer struct {
+	format  string
+	columns []Column
+	out     io.Writer
+	csv     *csv.Writer
+}
+
+// NewWriter creates a writer for the given format and writes the header if the format has one
+func NewWriter(out io.Writer, format string, columns []Column) (*Writer, error) {
+	w := &Writer{format: format, columns: columns, out: out}
+
+	switch format {
+	case FormatJSONL:
+		return w, nil
+	case FormatCSV, FormatExcel:
+	default:
+		return nil, fmt.Errorf("unsupported format %q (use %s)", format, strings.Join(Formats, ", "))
+	}
+
+	if format == FormatExcel {
+		// The byte order mark makes Excel read the file as UTF-8
+		if _, err := io.WriteString(out, "\ufeff"); err != nil {
+			return nil, err
+		}
+	}
+
+	w.csv = csv.NewWriter(out)
+	w.csv.UseCRLF = format == FormatExcel
+
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.Name
+	}
+	if err := w.csv.Write(header); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes a single issue
+func (w *Writer) Write(issue jira.RawIssue) error {
+	if w.format == FormatJSONL {
+		return w.writeJSON(issue)
+	}
+
+	record := make([]string, len(w.columns))
+	for i, c := range w.columns {
+		var cell string
+		switch c.ID {
+		case KeyColumn:
+			cell = issue.Key
+		case ChangelogColumn:
+			cell = formatChangelog(issue.Changelog)
+		default:
+			cell = jira.FormatFieldValue(issue.Fields[c.ID])
+		}
+		if w.format == FormatExcel {
+			cell = excelCell(cell)
+		}
+		record[i] = cell
+	}
+	return w.csv.Write(record)
+}
+
+// Flush writes any buffered data
+func (w *Writer) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// writeJSON writes one JSON object per line, with keys in column order and raw field values
+func (w *Writer) writeJSON(issue jira.RawIssue) error {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, c := range w.columns {
+		var value interface{}
+		switch c.ID {
+		case KeyColumn:
+			value = issue.Key
+		case ChangelogColumn:
+			if issue.Changelog != nil {
+				value = issue.Changelog.Histories
+			}
+		default:
+			value = issue.Fields[c.ID]
+		}
+
+		name, err := json.Marshal(c.Name)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(data)
+	}
+	buf.WriteString("}\n")
+
+	_, err := w.out.Write(buf.Bytes())
+	return err
+}
+
+// formatChangelog renders one line per changed field, oldest first
+func formatChangelog(changelog *gojira.Changelog) string {
+	if changelog == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, h := range changelog.Histories {
+		for _, item := range h.Items {
+			lines = append(lines, fmt.Sprintf("[%s] %s: %s: %s -> %s",
+				h.Created, h.Author.DisplayName, item.Field, item.FromString, item.ToString))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// excelCell keeps spreadsheet apps from evaluating cell text as a formula and
+// truncates values that exceed the cell size limit
+func excelCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		s = "'" + s
+	}
+	if runes := []rune(s); len(runes) > excelCellLimit {
+		s = string(runes[:excelCellLimit])
+	}
+	return s
+}
@@ -0,0 +1,242 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// DefaultKeyPattern matches Jira issue keys such as PROJ-123
+const DefaultKeyPattern = `[A-Z][A-Z0-9_]+-\d+`
+
+// DefaultBranchTemplate names branches like bug/PROJ-123-fix-login-redirect
+const DefaultBranchTemplate = `{{.Type | lower}}/{{.Key}}-{{.Summary | slug}}`
+
+// maxSlugLength caps the length of slugs so summaries don't produce unwieldy branch names
+const maxSlugLength = 50
+
+var ErrNoIssueKey = errors.New("no issue key found in branch name")
+
+// run executes git in the current directory and returns its trimmed output
+func run(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// CurrentBranch returns the name of the checked-out branch
+func CurrentBranch() (string, error) {
+	branch, err := run("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		// A new repository's branch has no commits for HEAD to resolve to yet
+		if unborn, symErr := run("symbolic-ref", "--short", "HEAD"); symErr == nil {
+			return unborn, nil
+		}
+		return "", err
+	}
+	if branch == "HEAD" {
+		return "", fmt.Errorf("not on a branch (detached HEAD)")
+	}
+	return branch, nil
+}
+
+// IssueKeyFromBranch extracts an issue key from a branch name. If the pattern has a
+// capture group, the first group is the key; otherwise the whole match is.
+func IssueKeyFromBranch(branch, pattern string) (string, error) {
+	if pattern == "" {
+		pattern = DefaultKeyPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid branch key pattern: %w", err)
+	}
+
+	m := re.FindStringSubmatch(branch)
+	if m == nil {
+		return "", ErrNoIssueKey
+	}
+	if len(m) > 1 && m[1] != "" {
+		return m[1], nil
+	}
+	return m[0], nil
+}
+
+// BranchIssueKey returns the issue key referenced by the current branch name
+func BranchIssueKey(pattern string) (string, error) {
+	branch, err := CurrentBranch()
+	if err != nil {
+		return "", err
+	}
+	return IssueKeyFromBranch(branch, pattern)
+}
+
+// InWorkTree reports whether the current directory is inside a git work tree
+func InWorkTree() bool {
+	out, err := run("rev-parse", "--is-inside-work-tree")
+	return err == nil && out == "true"
+}
+
+// BranchIssue holds the issue fields available to branch name templates
+type BranchIssue struct {
+	Key     string
+	Project string
+	Type    string
+	Summary string
+}
+
+var branchFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"slug":  Slug,
+}
+
+// BranchName renders a branch name template for an issue. Whitespace left in the result
+// (e.g. from a multi-word issue type) becomes dashes.
+func BranchName(tmpl string, issue BranchIssue) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultBranchTemplate
+	}
+	t, err := template.New("branch").Funcs(branchFuncs).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid branch template: %w", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, issue); err != nil {
+		return "", fmt.Errorf("invalid branch template: %w", err)
+	}
+
+	name := strings.Join(strings.Fields(b.String()), "-")
+	if _, err := run("check-ref-format", "--branch", name); err != nil {
+		return "", fmt.Errorf("invalid branch name %q", name)
+	}
+	return name, nil
+}
+
+// Slug lower-cases s and joins its words with dashes, dropping punctuation
+func Slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		// Cut at a word boundary, or mid-word if the first word is that long
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+		slug = strings.ToValidUTF8(slug, "")
+	}
+	return slug
+}
+
+// BranchExists reports whether a local branch exists
+func BranchExists(name string) bool {
+	_, err := run("rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+	return err == nil
+}
+
+// Checkout switches to a branch, creating it from HEAD when create is set
+func Checkout(name string, create bool) error {
+	args := []string{"checkout", name}
+	if create {
+		args = []string{"checkout", "-b", name}
+	}
+	_, err := run(args...)
+	return err
+}
+
+// HooksDir returns the directory git runs hooks from, honouring core.hooksPath
+func HooksDir() (string, error) {
+	dir, err := run("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(dir)
+}
+
+// Merging reports whether a merge is being concluded
+func Merging() bool {
+	_, err := run("rev-parse", "--quiet", "--verify", "MERGE_HEAD")
+	return err == nil
+}
+
+// messageKeyPattern finds issue keys in commit messages. Unlike branch names, messages are
+// not matched with the configured key pattern: that pattern may be anchored to a branch
+// naming scheme, and the keys written into messages are always in Jira's PROJ-123 form.
+var messageKeyPattern = regexp.MustCompile(`\b` + DefaultKeyPattern + `\b`)
+
+// MessageIssueKeys returns the distinct issue keys mentioned in a commit message, in
+// Jira's upper-case form whatever the configured branch key pattern is
+func MessageIssueKeys(message string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range messageKeyPattern.FindAllString(message, -1) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Commit is a commit listed by Log
+type Commit struct {
+	Hash    string
+	Subject string
+	// Message is the full commit message, subject included
+	Message string
+}
+
+// Log returns the commits reachable from to but not from from, newest first. An empty
+// from lists all of to's history.
+func Log(from, to string) ([]Commit, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	rev := to
+	if from != "" {
+		rev = from + ".." + to
+	}
+	// Records end with RS and fields are separated by US, which don't appear in messages
+	out, err := run("log", "--format=%H%x1f%s%x1f%B%x1e", rev)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		commits = append(commits, Commit{Hash: fields[0], Subject: fields[1], Message: fields[2]})
+	}
+	return commits, nil
+}
@@ -0,0 +1,288 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Record is a single issue to create from an import file
+type Record struct {
+	// Ref identifies the record within the file so other records can use it as their parent.
+	// It defaults to "#<row>".
+	Ref         string
+	Parent      string
+	Project     string
+	IssueType   string
+	Summary     string
+	Description string
+	Labels      []string
+	Components  []string
+	// Fields holds other field values keyed by field name or ID
+	Fields map[string]string
+	// Row is the 1-based position of the record in the file
+	Row int
+}
+
+var issueKeyRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-\d+$`)
+
+// IsIssueKey reports whether s looks like an existing issue key such as PROJ-123
+func IsIssueKey(s string) bool {
+	return issueKeyRe.MatchString(s)
+}
+
+// ReadFile parses records from a CSV, YAML or JSON file. The format is taken from
+// format if set, otherwise from the file extension. mapping renames input columns
+// or keys before they are interpreted, e.g. {"Points": "Story Points"}.
+func ReadFile(path, format string, mapping map[string]string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer f.Close()
+
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	var rows []map[string]interface{}
+	switch format {
+	case "csv":
+		rows, err = readCSV(f)
+	case "yaml", "yml":
+		rows, err = readYAML(f)
+	case "json":
+		rows, err = readJSON(f)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q (use csv, yaml or json)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, len(rows))
+	for i, row := range rows {
+		records[i] = recordFromRow(row, mapping, i+1)
+	}
+	return records, nil
+}
+
+func readCSV(r io.Reader) ([]map[string]interface{}, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	lines, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	header := lines[0]
+	rows := make([]map[string]interface{}, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		row := make(map[string]interface{}, len(header))
+		for i, col := range header {
+			if i < len(line) && strings.TrimSpace(line[i]) != "" {
+				row[strings.TrimSpace(col)] = line[i]
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func readYAML(r io.Reader) ([]map[string]interface{}, error) {
+	var doc interface{}
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	return documentRows(doc)
+}
+
+func readJSON(r io.Reader) ([]map[string]interface{}, error) {
+	var doc interface{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return documentRows(doc)
+}
+
+// documentRows accepts either a list of issues or an object with an "issues" list
+func documentRows(doc interface{}) ([]map[string]interface{}, error) {
+	if m, ok := doc.(map[string]interface{}); ok {
+		doc = m["issues"]
+	}
+
+	list, ok := doc.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list of issues or an object with an \"issues\" list")
+	}
+
+	rows := make([]map[string]interface{}, len(list))
+	for i, item := range list {
+		row, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("issue %d is not an object", i+1)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// recordFromRow interprets well-known keys and treats everything else as a field name
+func recordFromRow(row map[string]interface{}, mapping map[string]string, n int) Record {
+	rec := Record{Row: n, Fields: map[string]string{}}
+
+	for key, value := range row {
+		if target, ok := mapping[key]; ok {
+			key = target
+		}
+
+		switch strings.ToLower(strings.ReplaceAll(key, " ", "_")) {
+		case "ref", "id":
+			rec.Ref = stringValue(value)
+		case "parent":
+			rec.Parent = stringValue(value)
+		case "project":
+			rec.Project = stringValue(value)
+		case "type", "issue_type", "issuetype":
+			rec.IssueType = stringValue(value)
+		case "summary":
+			rec.Summary = stringValue(value)
+		case "description":
+			rec.Description = stringValue(value)
+		case "labels":
+			rec.Labels = listValue(value)
+		case "components":
+			rec.Components = listValue(value)
+		case "fields":
+			if m, ok := value.(map[string]interface{}); ok {
+				for name, v := range m {
+					rec.Fields[name] = stringValue(v)
+				}
+			}
+		default:
+			rec.Fields[key] = stringValue(value)
+		}
+	}
+
+	if rec.Ref == "" {
+		rec.Ref = fmt.Sprintf("#%d", n)
+	}
+	return rec
+}
+
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(val)
+	case []interface{}:
+		return strings.Join(listValue(val), ",")
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
+
+func listValue(v interface{}) []string {
+	var parts []string
+	switch val := v.(type) {
+	case []interface{}:
+		for _, item := range val {
+			parts = append(parts, stringValue(item))
+		}
+	default:
+		parts = strings.Split(stringValue(v), ",")
+	}
+
+	var result []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Levels orders records so every record comes after its local parent. Records in the
+// same level do not depend on each other and can be created concurrently.
+// Parents that are not local refs must look like existing issue keys.
+func Levels(records []Record) ([][]int, error) {
+	byRef := make(map[string]int, len(records))
+	for i, rec := range records {
+		if prev, ok := byRef[rec.Ref]; ok {
+			return nil, fmt.Errorf("duplicate ref %q in rows %d and %d", rec.Ref, records[prev].Row, rec.Row)
+		}
+		byRef[rec.Ref] = i
+	}
+
+	depth := make([]int, len(records))
+	for i := range depth {
+		depth[i] = -1
+	}
+
+	var visit func(i int, path map[int]bool) (int, error)
+	visit = func(i int, path map[int]bool) (int, error) {
+		if depth[i] >= 0 {
+			return depth[i], nil
+		}
+		rec := records[i]
+		if rec.Parent == "" {
+			depth[i] = 0
+			return 0, nil
+		}
+		p, ok := byRef[rec.Parent]
+		if !ok {
+			if !IsIssueKey(rec.Parent) {
+				return 0, fmt.Errorf("row %d: unknown parent %q", rec.Row, rec.Parent)
+			}
+			depth[i] = 0
+			return 0, nil
+		}
+		if path[p] {
+			return 0, fmt.Errorf("row %d: parent cycle involving %q", rec.Row, rec.Parent)
+		}
+		path[i] = true
+		d, err := visit(p, path)
+		if err != nil {
+			return 0, err
+		}
+		depth[i] = d + 1
+		return depth[i], nil
+	}
+
+	maxDepth := 0
+	for i := range records {
+		d, err := visit(i, map[int]bool{})
+		if err != nil {
+			return nil, err
+		}
+		if d > maxDepth {
+			maxDepth = d
+		}
+	}
+
+	levels := make([][]int, maxDepth+1)
+	for i, d := range depth {
+		levels[d] = append(levels[d], i)
+	}
+	for _, level := range levels {
+		sort.Ints(level)
+	}
+	return levels, nil
+}
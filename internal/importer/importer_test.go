@@ -0,0 +1,85 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// records builds records from "ref:parent" pairs, with rows numbered from 1
+func records(specs ...string) []Record {
+	recs := make([]Record, len(specs))
+	for i, spec := range specs {
+		ref, parent, _ := strings.Cut(spec, ":")
+		recs[i] = Record{Ref: ref, Parent: parent, Row: i + 1}
+	}
+	return recs
+}
+
+func TestLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []Record
+		want    [][]int
+		wantErr string
+	}{
+		{
+			name:    "no parents",
+			records: records("a", "b", "c"),
+			want:    [][]int{{0, 1, 2}},
+		},
+		{
+			name:    "chained parents",
+			records: records("story:epic", "task:story", "epic", "subtask:task"),
+			want:    [][]int{{2}, {0}, {1}, {3}},
+		},
+		{
+			name:    "siblings share a level",
+			records: records("epic", "s1:epic", "s2:epic", "t1:s1"),
+			want:    [][]int{{0}, {1, 2}, {3}},
+		},
+		{
+			name:    "existing issue as parent",
+			records: records("a:PROJ-12", "b:a"),
+			want:    [][]int{{0}, {1}},
+		},
+		{
+			name:    "missing parent",
+			records: records("a", "b:nope"),
+			wantErr: `row 2: unknown parent "nope"`,
+		},
+		{
+			name:    "cycle",
+			records: records("a:c", "b:a", "c:b"),
+			wantErr: "parent cycle",
+		},
+		{
+			name:    "own parent",
+			records: records("a:a"),
+			wantErr: "parent cycle",
+		},
+		{
+			name:    "duplicate ref",
+			records: records("a", "a"),
+			wantErr: `duplicate ref "a" in rows 1 and 2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Levels(tt.records)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Levels() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Levels() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Levels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
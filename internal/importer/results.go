@@ -0,0 +1,83 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Result records an issue created from an import file
+type Result struct {
+	Ref     string `json:"ref"`
+	Key     string `json:"key"`
+	Row     int    `json:"row"`
+	Summary string `json:"summary"`
+}
+
+// LoadResults reads a results file written by a previous run and returns the created
+// issue keys by ref. A missing file yields an empty map.
+func LoadResults(path string) (map[string]string, error) {
+	keys := map[string]string{}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open results file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Result
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("failed to parse results file: %w", err)
+		}
+		keys[r.Ref] = r.Key
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read results file: %w", err)
+	}
+	return keys, nil
+}
+
+// ResultWriter appends results as JSON Lines. It is safe for concurrent use.
+type ResultWriter struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewResultWriter opens path for appending, creating it if needed
+func NewResultWriter(path string) (*ResultWriter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open results file: %w", err)
+	}
+	return &ResultWriter{f: f}, nil
+}
+
+// Write appends a result and flushes it to disk so an interrupted run can resume
+func (w *ResultWriter) Write(r Result) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	return w.f.Sync()
+}
+
+// Close closes the underlying file
+func (w *ResultWriter) Close() error {
+	return w.f.Close()
+}
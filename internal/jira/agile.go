@@ -0,0 +1,377 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// Sprint states
+const (
+	SprintFuture = "future"
+	SprintActive = "active"
+	SprintClosed = "closed"
+)
+
+// agilePageSize is the page size requested from the Agile API (its maximum is 50)
+const agilePageSize = 50
+
+// Board is an agile board
+type Board struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Location struct {
+		ProjectKey  string `json:"projectKey"`
+		DisplayName string `json:"displayName"`
+	} `json:"location"`
+}
+
+// Sprint is a sprint on a scrum board
+type Sprint struct {
+	ID            int        `json:"id"`
+	Name          string     `json:"name"`
+	State         string     `json:"state"`
+	Goal          string     `json:"goal,omitempty"`
+	StartDate     *time.Time `json:"startDate,omitempty"`
+	EndDate       *time.Time `json:"endDate,omitempty"`
+	CompleteDate  *time.Time `json:"completeDate,omitempty"`
+	OriginBoardID int        `json:"originBoardId,omitempty"`
+}
+
+// BoardEstimation is how a board estimates issues. Type is "field" when a field such as
+// Story Points is used, or "issueCount" when issues are simply counted.
+type BoardEstimation struct {
+	Type  string `json:"type"`
+	Field struct {
+		FieldID     string `json:"fieldId"`
+		DisplayName string `json:"displayName"`
+	} `json:"field"`
+}
+
+// QuickFilter is a board's named JQL clause that narrows the issues shown
+type QuickFilter struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	JQL  string `json:"jql"`
+}
+
+// agileGet fetches one page from the Agile API into v
+func (c *Client) agileGet(endpoint string, params url.Values, v interface{}) error {
+	req, err := c.NewRequest("GET", endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.Do(req, v)
+	if err != nil {
+		return newAPIError(resp, err)
+	}
+	return nil
+}
+
+// GetBoards returns the boards for a project, or all boards visible to the user if project is empty
+func (c *Client) GetBoards(project string) ([]Board, error) {
+	var boards []Board
+	for startAt := 0; ; {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", strconv.Itoa(agilePageSize))
+		if project != "" {
+			params.Set("projectKeyOrId", project)
+		}
+
+		var page struct {
+			Values []Board `json:"values"`
+			IsLast bool    `json:"isLast"`
+		}
+		if err := c.agileGet("rest/agile/1.0/board", params, &page); err != nil {
+			return nil, fmt.Errorf("failed to get boards: %w", err)
+		}
+		boards = append(boards, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return boards, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// GetBoard returns a board by ID
+func (c *Client) GetBoard(id int) (*Board, error) {
+	var board Board
+	if err := c.agileGet(fmt.Sprintf("rest/agile/1.0/board/%d", id), url.Values{}, &board); err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+	return &board, nil
+}
+
+// GetBoardConfiguration returns a board's filter and column configuration
+func (c *Client) GetBoardConfiguration(id int) (*jira.BoardConfiguration, error) {
+	config, resp, err := c.Board.GetBoardConfiguration(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board configuration: %w", newAPIError(resp, err))
+	}
+	return config, nil
+}
+
+// GetBoardEstimation returns the estimation setting from a board's configuration
+func (c *Client) GetBoardEstimation(id int) (*BoardEstimation, error) {
+	var config struct {
+		Estimation BoardEstimation `json:"estimation"`
+	}
+	if err := c.agileGet(fmt.Sprintf("rest/agile/1.0/board/%d/configuration", id), url.Values{}, &config); err != nil {
+		return nil, fmt.Errorf("failed to get board configuration: %w", err)
+	}
+	return &config.Estimation, nil
+}
+
+// GetSprints returns a board's sprints in the given states (all states if none are given)
+func (c *Client) GetSprints(boardID int, states ...string) ([]Sprint, error) {
+	var sprints []Sprint
+	for startAt := 0; ; {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", strconv.Itoa(agilePageSize))
+		if len(states) > 0 {
+			params.Set("state", strings.Join(states, ","))
+		}
+
+		var page struct {
+			Values []Sprint `json:"values"`
+			IsLast bool     `json:"isLast"`
+		}
+		if err := c.agileGet(fmt.Sprintf("rest/agile/1.0/board/%d/sprint", boardID), params, &page); err != nil {
+			return nil, fmt.Errorf("failed to get sprints: %w", err)
+		}
+		sprints = append(sprints, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return sprints, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// GetSprint returns a sprint by ID
+func (c *Client) GetSprint(id int) (*Sprint, error) {
+	var sprint Sprint
+	if err := c.agileGet(fmt.Sprintf("rest/agile/1.0/sprint/%d", id), url.Values{}, &sprint); err != nil {
+		return nil, fmt.Errorf("failed to get sprint: %w", err)
+	}
+	return &sprint, nil
+}
+
+// UpdateSprint changes the given sprint fields, e.g. state, startDate, endDate or goal
+func (c *Client) UpdateSprint(id int, fields map[string]interface{}) (*Sprint, error) {
+	req, err := c.NewRequest("POST", fmt.Sprintf("rest/agile/1.0/sprint/%d", id), fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var sprint Sprint
+	resp, err := c.Do(req, &sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sprint: %w", newAPIError(resp, err))
+	}
+	return &sprint, nil
+}
+
+// StartSprint makes a future sprint active, running from now until end
+func (c *Client) StartSprint(id int, end time.Time, goal string) (*Sprint, error) {
+	fields := map[string]interface{}{
+		"state":     SprintActive,
+		"startDate": time.Now().Format(time.RFC3339),
+		"endDate":   end.Format(time.RFC3339),
+	}
+	if goal != "" {
+		fields["goal"] = goal
+	}
+	return c.UpdateSprint(id, fields)
+}
+
+// CloseSprint completes an active sprint
+func (c *Client) CloseSprint(id int) (*Sprint, error) {
+	return c.UpdateSprint(id, map[string]interface{}{"state": SprintClosed})
+}
+
+// MoveIssuesToSprint moves issues (by key or ID) into a sprint
+func (c *Client) MoveIssuesToSprint(sprintID int, keys []string) error {
+	for _, batch := range batches(keys, agilePageSize) {
+		resp, err := c.Sprint.MoveIssuesToSprint(sprintID, batch)
+		if err != nil {
+			return fmt.Errorf("failed to move issues to sprint: %w", newAPIError(resp, err))
+		}
+	}
+	return nil
+}
+
+// MoveIssuesToBacklog removes issues (by key or ID) from their sprints
+func (c *Client) MoveIssuesToBacklog(keys []string) error {
+	for _, batch := range batches(keys, agilePageSize) {
+		req, err := c.NewRequest("POST", "rest/agile/1.0/backlog/issue", map[string]interface{}{"issues": batch})
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		resp, err := c.Do(req, nil)
+		if err != nil {
+			return fmt.Errorf("failed to move issues to backlog: %w", newAPIError(resp, err))
+		}
+	}
+	return nil
+}
+
+// RankIssues moves issues, keeping their order, directly before or after another issue.
+// Exactly one of before and after must be set.
+func (c *Client) RankIssues(keys []string, before, after string) error {
+	if (before == "") == (after == "") {
+		return fmt.Errorf("rank needs exactly one of before or after")
+	}
+
+	for i, batch := range batches(keys, agilePageSize) {
+		body := map[string]interface{}{"issues": batch}
+		switch {
+		case i > 0:
+			// Later batches follow the last issue of the previous one
+			body["rankAfterIssue"] = keys[i*agilePageSize-1]
+		case before != "":
+			body["rankBeforeIssue"] = before
+		default:
+			body["rankAfterIssue"] = after
+		}
+
+		req, err := c.NewRequest("PUT", "rest/agile/1.0/issue/rank", body)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := c.Do(req, nil)
+		if err != nil {
+			return fmt.Errorf("failed to rank issues: %w", newAPIError(resp, err))
+		}
+		defer resp.Body.Close()
+
+		// Success is 204 No Content; 207 lists the issues that could not be ranked
+		if resp.StatusCode != http.StatusMultiStatus {
+			continue
+		}
+		var result struct {
+			Entries []struct {
+				IssueKey string   `json:"issueKey"`
+				Status   int      `json:"status"`
+				Errors   []string `json:"errors"`
+			} `json:"entries"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		var failed []string
+		for _, e := range result.Entries {
+			if e.Status >= 300 {
+				failed = append(failed, fmt.Sprintf("%s: %s", e.IssueKey, strings.Join(e.Errors, ", ")))
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to rank issues: %s", strings.Join(failed, "; "))
+		}
+	}
+	return nil
+}
+
+// GetSprintIssues returns the issues in a sprint in rank order, optionally narrowed by jql
+func (c *Client) GetSprintIssues(sprintID int, jql string, fields []string) ([]RawIssue, error) {
+	issues, err := c.agileIssues(fmt.Sprintf("rest/agile/1.0/sprint/%d/issue", sprintID), jql, fields, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sprint issues: %w", err)
+	}
+	return issues, nil
+}
+
+// GetBacklog returns up to limit issues from a board's backlog in rank order; zero means no limit
+func (c *Client) GetBacklog(boardID int, fields []string, limit int) ([]RawIssue, error) {
+	issues, err := c.agileIssues(fmt.Sprintf("rest/agile/1.0/board/%d/backlog", boardID), "", fields, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backlog: %w", err)
+	}
+	return issues, nil
+}
+
+// GetBoardIssues returns the issues on a board in rank order, optionally narrowed by jql
+func (c *Client) GetBoardIssues(boardID int, jql string, fields []string) ([]RawIssue, error) {
+	issues, err := c.agileIssues(fmt.Sprintf("rest/agile/1.0/board/%d/issue", boardID), jql, fields, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board issues: %w", err)
+	}
+	return issues, nil
+}
+
+// GetQuickFilters returns a board's quick filters
+func (c *Client) GetQuickFilters(boardID int) ([]QuickFilter, error) {
+	var filters []QuickFilter
+	for startAt := 0; ; {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", strconv.Itoa(agilePageSize))
+
+		var page struct {
+			Values []QuickFilter `json:"values"`
+			IsLast bool          `json:"isLast"`
+		}
+		if err := c.agileGet(fmt.Sprintf("rest/agile/1.0/board/%d/quickfilter", boardID), params, &page); err != nil {
+			return nil, fmt.Errorf("failed to get quick filters: %w", err)
+		}
+		filters = append(filters, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return filters, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// agileIssues pages through an Agile API issue list
+func (c *Client) agileIssues(endpoint, jql string, fields []string, limit int) ([]RawIssue, error) {
+	var issues []RawIssue
+	for {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(len(issues)))
+		params.Set("maxResults", strconv.Itoa(agilePageSize))
+		if len(fields) > 0 {
+			params.Set("fields", strings.Join(fields, ","))
+		}
+		if jql != "" {
+			params.Set("jql", jql)
+		}
+
+		var page struct {
+			Issues []RawIssue `json:"issues"`
+			Total  int        `json:"total"`
+		}
+		if err := c.agileGet(endpoint, params, &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+
+		if limit > 0 && len(issues) >= limit {
+			return issues[:limit], nil
+		}
+		if len(page.Issues) == 0 || len(issues) >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+// batches splits items into slices of at most size elements
+func batches(items []string, size int) [][]string {
+	var out [][]string
+	for len(items) > size {
+		out = append(out, items[:size])
+		items = items[size:]
+	}
+	if len(items) > 0 {
+		out = append(out, items)
+	}
+	return out
+}
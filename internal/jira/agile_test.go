@@ -0,0 +1,121 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	jira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/config"
+)
+
+// rankRequest is the body of a rank call
+type rankRequest struct {
+	Issues          []string `json:"issues"`
+	RankBeforeIssue string   `json:"rankBeforeIssue,omitempty"`
+	RankAfterIssue  string   `json:"rankAfterIssue,omitempty"`
+}
+
+// newRankServer returns a client whose rank calls are recorded in requests
+func newRankServer(t *testing.T, requests *[]rankRequest) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/agile/1.0/issue/rank" {
+			http.NotFound(w, r)
+			return
+		}
+		var req rankRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*requests = append(*requests, req)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := jira.NewClient(srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{Client: client, config: &config.Config{Server: srv.URL}}
+}
+
+func issueKeys(from, to int) []string {
+	var keys []string
+	for i := from; i <= to; i++ {
+		keys = append(keys, fmt.Sprintf("PROJ-%d", i))
+	}
+	return keys
+}
+
+func TestRankIssues(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []string
+		before string
+		after  string
+		want   []rankRequest
+	}{
+		{
+			name:   "one batch before",
+			keys:   issueKeys(1, 3),
+			before: "PROJ-100",
+			want:   []rankRequest{{Issues: issueKeys(1, 3), RankBeforeIssue: "PROJ-100"}},
+		},
+		{
+			name:  "one full batch after",
+			keys:  issueKeys(1, 50),
+			after: "PROJ-100",
+			want:  []rankRequest{{Issues: issueKeys(1, 50), RankAfterIssue: "PROJ-100"}},
+		},
+		{
+			name:   "later batches follow the previous one",
+			keys:   issueKeys(1, 120),
+			before: "PROJ-500",
+			want: []rankRequest{
+				{Issues: issueKeys(1, 50), RankBeforeIssue: "PROJ-500"},
+				{Issues: issueKeys(51, 100), RankAfterIssue: "PROJ-50"},
+				{Issues: issueKeys(101, 120), RankAfterIssue: "PROJ-100"},
+			},
+		},
+		{
+			name:  "after with two batches",
+			keys:  issueKeys(1, 51),
+			after: "PROJ-500",
+			want: []rankRequest{
+				{Issues: issueKeys(1, 50), RankAfterIssue: "PROJ-500"},
+				{Issues: issueKeys(51, 51), RankAfterIssue: "PROJ-50"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []rankRequest
+			client := newRankServer(t, &got)
+			if err := client.RankIssues(tt.keys, tt.before, tt.after); err != nil {
+				t.Fatalf("RankIssues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RankIssues() sent %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankIssuesNeedsOneAnchor(t *testing.T) {
+	var got []rankRequest
+	client := newRankServer(t, &got)
+	for _, anchors := range [][2]string{{"", ""}, {"PROJ-1", "PROJ-2"}} {
+		if err := client.RankIssues(issueKeys(3, 4), anchors[0], anchors[1]); err == nil {
+			t.Errorf("RankIssues(before=%q, after=%q) error = nil, want error", anchors[0], anchors[1])
+		}
+	}
+	if len(got) != 0 {
+		t.Errorf("RankIssues() sent %d requests, want none", len(got))
+	}
+}
@@ -3,7 +3,6 @@ package jira
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/url"
 	"strings"
 
@@ -57,6 +56,12 @@ func NewClient(cfg *config.Config) (*Client, error) {
 // CreateIssueOptions contains optional fields for issue creation
 type CreateIssueOptions struct {
 	EpicLink string
+	// Parent is the key of the parent issue when creating a sub-task
+	Parent     string
+	Labels     []string
+	Components []string
+	// Fields holds additional field values keyed by field ID, see ResolveFieldValues
+	Fields map[string]interface{}
 }
 
 // CreateIssue creates a new issue in Jira
@@ -74,6 +79,16 @@ func (c *Client) CreateIssue(project, issueType, summary, description string, op
 		},
 	}
 
+	if opts != nil {
+		if opts.Parent != "" {
+			issue.Fields.Parent = &jira.Parent{Key: opts.Parent}
+		}
+		issue.Fields.Labels = opts.Labels
+		for _, name := range opts.Components {
+			issue.Fields.Components = append(issue.Fields.Components, &jira.Component{Name: name})
+		}
+	}
+
 	// Apply defaults from config
 	if c.config.IssueDefaults.Assignee != "" && issue.Fields.Assignee == nil {
 		issue.Fields.Assignee = &jira.User{Name: c.config.IssueDefaults.Assignee}
@@ -82,36 +97,51 @@ func (c *Client) CreateIssue(project, issueType, summary, description string, op
 		issue.Fields.Labels = c.config.IssueDefaults.Labels
 	}
 
+	extra := map[string]interface{}{}
+	if opts != nil {
+		for id, value := range opts.Fields {
+			extra[id] = value
+		}
+	}
+
 	// Apply epic link if provided
 	epicLink := ""
 	if opts != nil && opts.EpicLink != "" {
 		epicLink = opts.EpicLink
-	} else if c.config.IssueDefaults.EpicLink != "" {
+	} else if c.config.IssueDefaults.EpicLink != "" && (opts == nil || opts.Parent == "") {
+		// Sub-tasks inherit their epic from the parent, so the default only applies to top-level issues
 		epicLink = c.config.IssueDefaults.EpicLink
 	}
 
 	if epicLink != "" {
-		// Epic Link is typically a custom field. In Jira Cloud, it's often "parent" for next-gen projects
-		// or a custom field like "customfield_10014" for classic projects.
-		// We'll use the parent field which works for next-gen/team-managed projects.
-		issue.Fields.Parent = &jira.Parent{Key: epicLink}
+		// Team-managed projects link epics through the parent field, while
+		// company-managed projects use the "Epic Link" custom field
+		field, err := c.epicLinkField(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine epic link field: %w", err)
+		}
+		if field == ParentField {
+			issue.Fields.Parent = &jira.Parent{Key: epicLink}
+		} else {
+			extra[field] = epicLink
+		}
+	}
+
+	if len(extra) > 0 {
+		issue.Fields.Unknowns = extra
 	}
 
 	created, resp, err := c.Issue.Create(issue)
 	if err != nil {
-		if resp != nil && resp.Body != nil {
-			body, _ := io.ReadAll(resp.Body)
-			if len(body) > 0 {
-				return nil, fmt.Errorf("failed to create issue: %s", string(body))
-			}
-			return nil, fmt.Errorf("failed to create issue (status %d): %w", resp.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to create issue: %w", err)
+		return nil, fmt.Errorf("failed to create issue: %w", newAPIError(resp, err))
 	}
 
 	return created, nil
 }
 
+// DefaultSearchFields are the fields fetched when a search does not ask for specific ones
+var DefaultSearchFields = []string{"key", "summary", "status", "assignee", "priority", "created", "updated"}
+
 // SearchIssues searches for issues using JQL via the v3 API
 func (c *Client) SearchIssues(jql string, maxResults int) ([]jira.Issue, error) {
 	if maxResults <= 0 {
@@ -120,9 +150,10 @@ func (c *Client) SearchIssues(jql string, maxResults int) ([]jira.Issue, error)
 
 	// Use the v3 search/jql endpoint
 	apiEndpoint := fmt.Sprintf(
-		"rest/api/3/search/jql?jql=%s&maxResults=%d&fields=key,summary,status,assignee,priority,created,updated",
+		"rest/api/3/search/jql?jql=%s&maxResults=%d&fields=%s",
 		url.QueryEscape(jql),
 		maxResults,
+		strings.Join(DefaultSearchFields, ","),
 	)
 
 	req, err := c.NewRequest("GET", apiEndpoint, nil)
@@ -132,10 +163,7 @@ func (c *Client) SearchIssues(jql string, maxResults int) ([]jira.Issue, error)
 
 	resp, err := c.Do(req, nil)
 	if err != nil {
-		if resp != nil {
-			return nil, fmt.Errorf("search failed (status %d): %w", resp.StatusCode, err)
-		}
-		return nil, fmt.Errorf("search failed: %w", err)
+		return nil, fmt.Errorf("search failed: %w", newAPIError(resp, err))
 	}
 	defer resp.Body.Close()
 
@@ -147,14 +175,90 @@ func (c *Client) SearchIssues(jql string, maxResults int) ([]jira.Issue, error)
 	return result.Issues, nil
 }
 
+// SearchOptions controls what SearchAll returns for each issue
+type SearchOptions struct {
+	// Fields lists field IDs to return; "*all" and "*navigable" are also accepted
+	Fields []string
+	// Expand lists extra data to include, e.g. "changelog"
+	Expand []string
+	// PageSize is the number of issues requested per page (default 100)
+	PageSize int
+	// Limit stops the search after this many issues; zero means no limit
+	Limit int
+}
+
+// RawIssue is an issue whose fields are decoded generically, so any
+// system or custom field can be read by ID
+type RawIssue struct {
+	ID        string                 `json:"id"`
+	Key       string                 `json:"key"`
+	Fields    map[string]interface{} `json:"fields"`
+	Changelog *jira.Changelog        `json:"changelog,omitempty"`
+}
+
+// SearchAll runs a JQL search and pages through the whole result set, calling fn for each page.
+// It uses the v2 endpoint so rich-text fields come back as plain strings rather than ADF.
+func (c *Client) SearchAll(jql string, opts SearchOptions, fn func([]RawIssue) error) error {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	if opts.Limit > 0 && opts.Limit < pageSize {
+		pageSize = opts.Limit
+	}
+
+	fetched := 0
+	nextPageToken := ""
+	for {
+		params := url.Values{}
+		params.Set("jql", jql)
+		params.Set("maxResults", fmt.Sprintf("%d", pageSize))
+		if len(opts.Fields) > 0 {
+			params.Set("fields", strings.Join(opts.Fields, ","))
+		}
+		if len(opts.Expand) > 0 {
+			params.Set("expand", strings.Join(opts.Expand, ","))
+		}
+		if nextPageToken != "" {
+			params.Set("nextPageToken", nextPageToken)
+		}
+
+		req, err := c.NewRequest("GET", "rest/api/2/search/jql?"+params.Encode(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		var page struct {
+			Issues        []RawIssue `json:"issues"`
+			NextPageToken string     `json:"nextPageToken"`
+			IsLast        bool       `json:"isLast"`
+		}
+		resp, err := c.Do(req, &page)
+		if err != nil {
+			return fmt.Errorf("search failed: %w", newAPIError(resp, err))
+		}
+
+		if opts.Limit > 0 && fetched+len(page.Issues) > opts.Limit {
+			page.Issues = page.Issues[:opts.Limit-fetched]
+		}
+		fetched += len(page.Issues)
+
+		if err := fn(page.Issues); err != nil {
+			return err
+		}
+
+		if (opts.Limit > 0 && fetched >= opts.Limit) || page.IsLast || page.NextPageToken == "" || len(page.Issues) == 0 {
+			return nil
+		}
+		nextPageToken = page.NextPageToken
+	}
+}
+
 // GetIssue retrieves a single issue by key
 func (c *Client) GetIssue(key string) (*jira.Issue, error) {
 	issue, resp, err := c.Issue.Get(key, nil)
 	if err != nil {
-		if resp != nil {
-			return nil, fmt.Errorf("failed to get issue (status %d): %w", resp.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to get issue: %w", err)
+		return nil, fmt.Errorf("failed to get issue: %w", newAPIError(resp, err))
 	}
 	return issue, nil
 }
@@ -163,22 +267,62 @@ func (c *Client) GetIssue(key string) (*jira.Issue, error) {
 func (c *Client) GetIssueTypes(projectKey string) ([]jira.IssueType, error) {
 	project, resp, err := c.Project.Get(projectKey)
 	if err != nil {
-		if resp != nil {
-			return nil, fmt.Errorf("failed to get project (status %d): %w", resp.StatusCode, err)
-		}
-		return nil, fmt.Errorf("failed to get project: %w", err)
+		return nil, fmt.Errorf("failed to get project: %w", newAPIError(resp, err))
 	}
 	return project.IssueTypes, nil
 }
 
+// GetTransitions returns the workflow transitions available for an issue
+func (c *Client) GetTransitions(key string) ([]jira.Transition, error) {
+	transitions, resp, err := c.Issue.GetTransitions(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transitions: %w", newAPIError(resp, err))
+	}
+	return transitions, nil
+}
+
+// TransitionIssue moves an issue through the given workflow transition
+func (c *Client) TransitionIssue(key, transitionID string) error {
+	resp, err := c.Issue.DoTransition(key, transitionID)
+	if err != nil {
+		return fmt.Errorf("failed to transition %s: %w", key, newAPIError(resp, err))
+	}
+	return nil
+}
+
+// GetStatuses returns every workflow status with its category
+func (c *Client) GetStatuses() ([]jira.Status, error) {
+	statuses, resp, err := c.Status.GetAllStatuses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses: %w", newAPIError(resp, err))
+	}
+	return statuses, nil
+}
+
+// GetCurrentUser returns the authenticated user
+func (c *Client) GetCurrentUser() (*jira.User, error) {
+	user, resp, err := c.User.GetSelf()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", newAPIError(resp, err))
+	}
+	return user, nil
+}
+
+// AssignIssue sets the assignee of an issue
+func (c *Client) AssignIssue(key string, user *jira.User) error {
+	// Cloud identifies users by account ID, Server and Data Center by name
+	resp, err := c.Issue.UpdateAssignee(key, &jira.User{AccountID: user.AccountID, Name: user.Name})
+	if err != nil {
+		return fmt.Errorf("failed to assign %s: %w", key, newAPIError(resp, err))
+	}
+	return nil
+}
+
 // TestConnection verifies the connection to Jira works
 func (c *Client) TestConnection() error {
 	_, resp, err := c.User.GetSelf()
 	if err != nil {
-		if resp != nil {
-			return fmt.Errorf("connection test failed (status %d): %w", resp.StatusCode, err)
-		}
-		return fmt.Errorf("connection test failed: %w", err)
+		return fmt.Errorf("connection test failed: %w", newAPIError(resp, err))
 	}
 	return nil
 }
@@ -188,3 +332,19 @@ func (c *Client) GetEpics(projectKey string) ([]jira.Issue, error) {
 	jql := fmt.Sprintf("project = %s AND issuetype = Epic AND resolution = Unresolved ORDER BY created DESC", projectKey)
 	return c.SearchIssues(jql, 100)
 }
+
+// SearchUsers finds users whose name or email matches the query
+func (c *Client) SearchUsers(query string) ([]jira.User, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/user/search?query=%s&maxResults=50", url.QueryEscape(query))
+	req, err := c.NewRequest("GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var users []jira.User
+	resp, err := c.Do(req, &users)
+	if err != nil {
+		return nil, fmt.Errorf("user search failed: %w", newAPIError(resp, err))
+	}
+	return users, nil
+}
@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// APIError is an error response from the Jira REST API
+type APIError struct {
+	StatusCode int
+	// Messages are Jira's errorMessages, e.g. JQL syntax errors
+	Messages []string
+	// FieldErrors maps field IDs to the problem Jira found with their value
+	FieldErrors map[string]string
+	Err         error
+}
+
+func (e *APIError) Error() string {
+	details := append([]string(nil), e.Messages...)
+
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		details = append(details, fmt.Sprintf("%s: %s", field, e.FieldErrors[field]))
+	}
+
+	if len(details) == 0 {
+		if e.Err != nil {
+			return e.Err.Error()
+		}
+		return fmt.Sprintf("status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("%s (status %d)", strings.Join(details, "; "), e.StatusCode)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError builds an APIError from a failed request, reading Jira's error details
+// from the response. Without a response (e.g. a network error) err is returned as is.
+func newAPIError(resp *jira.Response, err error) error {
+	if resp == nil || resp.Response == nil {
+		return err
+	}
+
+	apiErr := &APIError{StatusCode: resp.StatusCode, Err: err}
+
+	// go-jira's services already consume the body into a *jira.Error
+	var jerr *jira.Error
+	if errors.As(err, &jerr) {
+		apiErr.Messages = jerr.ErrorMessages
+		apiErr.FieldErrors = jerr.Errors
+		if jerr.HTTPError != nil {
+			apiErr.Err = jerr.HTTPError
+		}
+		return apiErr
+	}
+
+	if resp.Body == nil {
+		return apiErr
+	}
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if readErr != nil || len(body) == 0 {
+		return apiErr
+	}
+
+	var payload struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Messages = payload.ErrorMessages
+		apiErr.FieldErrors = payload.Errors
+	}
+	return apiErr
+}
@@ -0,0 +1,498 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/cache"
+)
+
+const (
+	// fieldCacheTTL controls how long field metadata is reused before refetching
+	fieldCacheTTL = 24 * time.Hour
+
+	// epicLinkSchema is the custom field type Jira uses for "Epic Link" in company-managed projects
+	epicLinkSchema = "com.pyxis.greenhopper.jira:gh-epic-link"
+
+	// ParentField is the system field used to link issues to epics in team-managed projects
+	ParentField = "parent"
+
+	ProjectStyleClassic = "classic"
+	ProjectStyleNextGen = "next-gen"
+
+	// sprintSchema is the custom field type of the agile "Sprint" field
+	sprintSchema = "com.pyxis.greenhopper.jira:gh-sprint"
+)
+
+// FieldMeta describes a field as returned by the createmeta and editmeta endpoints
+type FieldMeta struct {
+	FieldID         string           `json:"fieldId"`
+	Key             string           `json:"key,omitempty"`
+	Name            string           `json:"name"`
+	Required        bool             `json:"required"`
+	HasDefaultValue bool             `json:"hasDefaultValue,omitempty"`
+	Schema          jira.FieldSchema `json:"schema"`
+	AllowedValues   []AllowedValue   `json:"allowedValues,omitempty"`
+	AutoCompleteURL string           `json:"autoCompleteUrl,omitempty"`
+	Operations      []string         `json:"operations,omitempty"`
+}
+
+// AllowedValue is one of the values a field accepts, such as a select option or version
+type AllowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+	Key   string `json:"key,omitempty"`
+}
+
+// Label returns the human-readable text of the allowed value
+func (v AllowedValue) Label() string {
+	switch {
+	case v.Name != "":
+		return v.Name
+	case v.Value != "":
+		return v.Value
+	case v.Key != "":
+		return v.Key
+	}
+	return v.ID
+}
+
+// IsEpicLink reports whether the field links an issue to its epic
+func (m FieldMeta) IsEpicLink() bool {
+	return m.FieldID == ParentField || m.Schema.Custom == epicLinkSchema
+}
+
+// FieldTypeName returns a short description of a field schema, e.g. "number" or "array<option>"
+func FieldTypeName(schema jira.FieldSchema) string {
+	if schema.Type == "array" && schema.Items != "" {
+		return "array<" + schema.Items + ">"
+	}
+	return schema.Type
+}
+
+// GetFields returns all system and custom fields defined on the server
+func (c *Client) GetFields() ([]jira.Field, error) {
+	fields, resp, err := c.Field.GetList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fields: %w", newAPIError(resp, err))
+	}
+	return fields, nil
+}
+
+// Fields returns all fields defined on the server, cached per server
+func (c *Client) Fields() ([]jira.Field, error) {
+	var fields []jira.Field
+	if ok, _ := cache.Load(c.config.Server, "fields", fieldCacheTTL, &fields); ok {
+		return fields, nil
+	}
+
+	fields, err := c.GetFields()
+	if err != nil {
+		return nil, err
+	}
+
+	_ = cache.Save(c.config.Server, "fields", fields)
+	return fields, nil
+}
+
+// RefreshFieldCache drops the cached field list and the entries derived from it for the
+// configured server. Other cached data, such as project styles, is kept.
+func (c *Client) RefreshFieldCache() error {
+	if err := cache.Remove(c.config.Server, "fields", "epic_link_field", "jql_autocomplete"); err != nil {
+		return err
+	}
+	return cache.RemovePrefix(c.config.Server, "createmeta_")
+}
+
+// ResolveField finds a field by ID, key, or human name (case-insensitive),
+// e.g. "customfield_10016" or "Story Points"
+func (c *Client) ResolveField(nameOrID string) (*jira.Field, error) {
+	fields, err := c.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	nameOrID = strings.TrimSpace(nameOrID)
+	for i := range fields {
+		if fields[i].ID == nameOrID || fields[i].Key == nameOrID {
+			return &fields[i], nil
+		}
+	}
+
+	var matches []*jira.Field
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, nameOrID) {
+			matches = append(matches, &fields[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown field: %s", nameOrID)
+	case 1:
+		return matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, f := range matches {
+		ids[i] = f.ID
+	}
+	return nil, fmt.Errorf("field name %q is ambiguous, use one of: %s", nameOrID, strings.Join(ids, ", "))
+}
+
+// ResolveFieldValues maps field names to field IDs and converts each raw string value
+// to the JSON shape its schema expects, ready to be sent as issue fields
+func (c *Client) ResolveFieldValues(values map[string]string) (map[string]interface{}, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolved := make(map[string]interface{}, len(values))
+	for _, name := range names {
+		field, err := c.ResolveField(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := FieldValue(field.Schema, values[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", field.Name, err)
+		}
+		resolved[field.ID] = value
+	}
+	return resolved, nil
+}
+
+var cfNameRe = regexp.MustCompile(`cf\[([^\]]*[^\]\d][^\]]*)\]`)
+
+// ExpandFieldNames rewrites cf[Field Name] references in JQL to cf[12345] using the
+// server's field list, so saved queries can refer to custom fields by name
+func (c *Client) ExpandFieldNames(jql string) (string, error) {
+	if !cfNameRe.MatchString(jql) {
+		return jql, nil
+	}
+
+	var resolveErr error
+	expanded := cfNameRe.ReplaceAllStringFunc(jql, func(m string) string {
+		name := strings.Trim(cfNameRe.FindStringSubmatch(m)[1], ` "'`)
+		field, err := c.ResolveField(name)
+		if err != nil {
+			if resolveErr == nil {
+				resolveErr = err
+			}
+			return m
+		}
+		if field.Schema.CustomID == 0 {
+			if resolveErr == nil {
+				resolveErr = fmt.Errorf("%s is not a custom field", field.Name)
+			}
+			return m
+		}
+		return fmt.Sprintf("cf[%d]", field.Schema.CustomID)
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return expanded, nil
+}
+
+// CreateMeta returns the fields available when creating an issue of the given type
+// in a project, cached per server
+func (c *Client) CreateMeta(projectKey, issueType string) ([]FieldMeta, error) {
+	cacheKey := "createmeta_" + projectKey + "_" + issueType
+	var fields []FieldMeta
+	if ok, _ := cache.Load(c.config.Server, cacheKey, fieldCacheTTL, &fields); ok {
+		return fields, nil
+	}
+
+	issueTypes, err := c.GetIssueTypes(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	typeID := ""
+	for _, it := range issueTypes {
+		if strings.EqualFold(it.Name, issueType) {
+			typeID = it.ID
+			break
+		}
+	}
+	if typeID == "" {
+		return nil, fmt.Errorf("issue type %q not found in project %s", issueType, projectKey)
+	}
+
+	for startAt := 0; ; {
+		apiEndpoint := fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes/%s?startAt=%d&maxResults=100", projectKey, typeID, startAt)
+		req, err := c.NewRequest("GET", apiEndpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Jira Cloud returns "fields", Data Center returns "values"
+		var page struct {
+			Fields []FieldMeta `json:"fields"`
+			Values []FieldMeta `json:"values"`
+			Total  int         `json:"total"`
+		}
+		resp, err := c.Do(req, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get create metadata: %w", newAPIError(resp, err))
+		}
+
+		batch := append(page.Fields, page.Values...)
+		fields = append(fields, batch...)
+		startAt += len(batch)
+		if len(batch) == 0 || page.Total == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	for i := range fields {
+		if fields[i].FieldID == "" {
+			fields[i].FieldID = fields[i].Key
+		}
+	}
+
+	_ = cache.Save(c.config.Server, cacheKey, fields)
+	return fields, nil
+}
+
+// EditMeta returns the fields that can be changed on an existing issue.
+// It is not cached since editability depends on the issue's current state.
+func (c *Client) EditMeta(issueKey string) ([]FieldMeta, error) {
+	req, err := c.NewRequest("GET", "rest/api/2/issue/"+issueKey+"/editmeta", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var meta struct {
+		Fields map[string]FieldMeta `json:"fields"`
+	}
+	resp, err := c.Do(req, &meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edit metadata: %w", newAPIError(resp, err))
+	}
+
+	fields := make([]FieldMeta, 0, len(meta.Fields))
+	for id, f := range meta.Fields {
+		f.FieldID = id
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields, nil
+}
+
+// UpdateIssueFields sets the given fields (keyed by field ID) on an existing issue
+func (c *Client) UpdateIssueFields(issueKey string, fields map[string]interface{}) error {
+	resp, err := c.Issue.UpdateIssue(issueKey, map[string]interface{}{"fields": fields})
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", newAPIError(resp, err))
+	}
+	return nil
+}
+
+// FieldValue converts a raw string into the JSON value Jira expects for a field schema.
+// Multi-value fields take a comma-separated list.
+func FieldValue(schema jira.FieldSchema, raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+
+	if schema.Custom == sprintSchema {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("sprint must be a numeric sprint ID")
+		}
+		return id, nil
+	}
+
+	if schema.Type == "array" {
+		var values []interface{}
+		for _, part := range strings.Split(raw, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			v, err := scalarFieldValue(schema.Items, part)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+
+	return scalarFieldValue(schema.Type, raw)
+}
+
+func scalarFieldValue(fieldType, raw string) (interface{}, error) {
+	switch fieldType {
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return n, nil
+	case "date":
+		if _, err := time.Parse("2006-01-02", raw); err != nil {
+			return nil, fmt.Errorf("%q is not a date (YYYY-MM-DD)", raw)
+		}
+		return raw, nil
+	case "datetime":
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04"} {
+			if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+				return t.Format("2006-01-02T15:04:05.000-0700"), nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not a date and time (YYYY-MM-DD HH:MM)", raw)
+	case "option":
+		return map[string]string{"value": raw}, nil
+	case "user":
+		return map[string]string{"accountId": raw}, nil
+	case "project":
+		return map[string]string{"key": raw}, nil
+	case "priority", "issuetype", "version", "component", "resolution", "group":
+		return map[string]string{"name": raw}, nil
+	}
+	return raw, nil
+}
+
+// FormatFieldValue renders a decoded field value as display text, e.g. a user as their
+// display name, an option as its value, and lists as comma-separated text
+func FormatFieldValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case []interface{}:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			if s := FormatFieldValue(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		if comments, ok := val["comments"].([]interface{}); ok {
+			return formatComments(comments)
+		}
+		for _, k := range []string{"displayName", "name", "value", "key"} {
+			if s, ok := val[k].(string); ok {
+				return s
+			}
+		}
+		data, _ := json.Marshal(val)
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+func formatComments(comments []interface{}) string {
+	parts := make([]string, 0, len(comments))
+	for _, c := range comments {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		author := FormatFieldValue(m["author"])
+		created := FormatFieldValue(m["created"])
+		parts = append(parts, fmt.Sprintf("[%s] %s: %s", created, author, FormatFieldValue(m["body"])))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// EpicLinkFieldID returns the ID of the "Epic Link" custom field, e.g. "customfield_10014".
+// The result is cached per server. An empty string means the server has no such field.
+func (c *Client) EpicLinkFieldID() (string, error) {
+	var id string
+	if ok, _ := cache.Load(c.config.Server, "epic_link_field", fieldCacheTTL, &id); ok {
+		return id, nil
+	}
+
+	fields, err := c.GetFields()
+	if err != nil {
+		return "", err
+	}
+
+	for _, f := range fields {
+		if f.Custom && f.Schema.Custom == epicLinkSchema {
+			id = f.ID
+			break
+		}
+	}
+
+	// Caching is best-effort; a failed write only means we fetch again next time
+	_ = cache.Save(c.config.Server, "epic_link_field", id)
+	return id, nil
+}
+
+// GetProjectStyle returns "classic" for company-managed projects and "next-gen" for team-managed ones
+func (c *Client) GetProjectStyle(projectKey string) (string, error) {
+	cacheKey := "project_style_" + projectKey
+	var style string
+	if ok, _ := cache.Load(c.config.Server, cacheKey, fieldCacheTTL, &style); ok {
+		return style, nil
+	}
+
+	req, err := c.NewRequest("GET", "rest/api/2/project/"+projectKey, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var project struct {
+		Style      string `json:"style"`
+		Simplified bool   `json:"simplified"`
+	}
+	resp, err := c.Do(req, &project)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", newAPIError(resp, err))
+	}
+
+	// Jira Server/Data Center does not report a style; all its projects are classic
+	style = project.Style
+	if style == "" {
+		style = ProjectStyleClassic
+		if project.Simplified {
+			style = ProjectStyleNextGen
+		}
+	}
+
+	_ = cache.Save(c.config.Server, cacheKey, style)
+	return style, nil
+}
+
+// epicLinkField decides which field links an issue to its epic in the given project.
+// The issue_defaults.epic_link_field config setting takes precedence over discovery.
+func (c *Client) epicLinkField(projectKey string) (string, error) {
+	if c.config.IssueDefaults.EpicLinkField != "" {
+		return c.config.IssueDefaults.EpicLinkField, nil
+	}
+
+	style, err := c.GetProjectStyle(projectKey)
+	if err != nil {
+		return "", err
+	}
+	if style != ProjectStyleClassic {
+		return ParentField, nil
+	}
+
+	id, err := c.EpicLinkFieldID()
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		// Newer Jira Cloud sites replaced Epic Link with parent for all projects
+		return ParentField, nil
+	}
+	return id, nil
+}
@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+const (
+	ShareNone    = "none"
+	ShareProject = "project"
+	// ShareAuthenticated shares with every logged-in user of the site
+	ShareAuthenticated = "authenticated"
+)
+
+// GetFilters returns the server-side filters the user owns or has marked as favourite
+func (c *Client) GetFilters() ([]*jira.Filter, error) {
+	filters, resp, err := c.Filter.GetMyFilters(&jira.GetMyFiltersQueryOptions{IncludeFavourites: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filters: %w", newAPIError(resp, err))
+	}
+
+	sort.Slice(filters, func(i, j int) bool { return filters[i].Name < filters[j].Name })
+	return filters, nil
+}
+
+// GetFilter returns a single server-side filter by ID
+func (c *Client) GetFilter(id string) (*jira.Filter, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter ID: %s", id)
+	}
+
+	filter, resp, err := c.Filter.Get(n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filter: %w", newAPIError(resp, err))
+	}
+	return filter, nil
+}
+
+// CreateFilterOptions contains the settings for a new server-side filter
+type CreateFilterOptions struct {
+	Description string
+	Favourite   bool
+	// Share is one of ShareNone, ShareProject or ShareAuthenticated
+	Share string
+	// ProjectKey is the project to share with when Share is ShareProject
+	ProjectKey string
+}
+
+// CreateFilter saves a JQL query as a server-side filter
+func (c *Client) CreateFilter(name, jql string, opts *CreateFilterOptions) (*jira.Filter, error) {
+	if opts == nil {
+		opts = &CreateFilterOptions{}
+	}
+
+	body := map[string]interface{}{
+		"name":        name,
+		"jql":         jql,
+		"description": opts.Description,
+		"favourite":   opts.Favourite,
+	}
+
+	switch opts.Share {
+	case "", ShareNone:
+	case ShareProject:
+		project, resp, err := c.Project.Get(opts.ProjectKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project: %w", newAPIError(resp, err))
+		}
+		body["sharePermissions"] = []map[string]interface{}{
+			{"type": "project", "project": map[string]string{"id": project.ID}},
+		}
+	case ShareAuthenticated:
+		body["sharePermissions"] = []map[string]interface{}{{"type": "authenticated"}}
+	default:
+		return nil, fmt.Errorf("unknown share option %q (use %s, %s or %s)", opts.Share, ShareNone, ShareProject, ShareAuthenticated)
+	}
+
+	req, err := c.NewRequest("POST", "rest/api/2/filter", body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var filter jira.Filter
+	resp, err := c.Do(req, &filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create filter: %w", newAPIError(resp, err))
+	}
+	return &filter, nil
+}
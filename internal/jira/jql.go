@@ -0,0 +1,134 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/eugenetaranov/jiractl/internal/cache"
+)
+
+// jqlPositionPattern matches the position Jira appends to JQL syntax errors
+var jqlPositionPattern = regexp.MustCompile(`\(line (\d+), character (\d+)\)`)
+
+// JQLError is a problem found in a JQL query. Line and Column are 1-based and
+// zero when Jira does not report a position, e.g. for unknown fields.
+type JQLError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+func (e JQLError) Error() string {
+	return e.Message
+}
+
+// ValidateJQL checks a query with Jira's JQL parser and returns the errors found, if any
+func (c *Client) ValidateJQL(jql string) ([]JQLError, error) {
+	body := map[string]interface{}{"queries": []string{jql}}
+	req, err := c.NewRequest("POST", "rest/api/3/jql/parse?validation=strict", body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result struct {
+		Queries []struct {
+			Query  string   `json:"query"`
+			Errors []string `json:"errors"`
+		} `json:"queries"`
+	}
+	resp, err := c.Do(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JQL: %w", newAPIError(resp, err))
+	}
+	if len(result.Queries) == 0 {
+		return nil, nil
+	}
+
+	var errs []JQLError
+	for _, msg := range result.Queries[0].Errors {
+		e := JQLError{Message: msg}
+		if m := jqlPositionPattern.FindStringSubmatch(msg); m != nil {
+			e.Line, _ = strconv.Atoi(m[1])
+			e.Column, _ = strconv.Atoi(m[2])
+		}
+		errs = append(errs, e)
+	}
+	return errs, nil
+}
+
+// JQLField is a field that can be used in JQL
+type JQLField struct {
+	// Value is the name to use in JQL, quoted if it contains spaces
+	Value       string   `json:"value"`
+	DisplayName string   `json:"displayName"`
+	Orderable   string   `json:"orderable"`
+	Searchable  string   `json:"searchable"`
+	CfID        string   `json:"cfid,omitempty"`
+	Operators   []string `json:"operators"`
+	Types       []string `json:"types"`
+}
+
+// JQLFunction is a function that can be used as a JQL value, e.g. currentUser()
+type JQLFunction struct {
+	Value       string   `json:"value"`
+	DisplayName string   `json:"displayName"`
+	IsList      string   `json:"isList,omitempty"`
+	Types       []string `json:"types"`
+}
+
+// JQLAutocompleteData lists the fields, functions and reserved words available in JQL
+type JQLAutocompleteData struct {
+	Fields        []JQLField    `json:"visibleFieldNames"`
+	Functions     []JQLFunction `json:"visibleFunctionNames"`
+	ReservedWords []string      `json:"jqlReservedWords"`
+}
+
+// JQLSuggestion is a possible value for a field
+type JQLSuggestion struct {
+	Value       string `json:"value"`
+	DisplayName string `json:"displayName"`
+}
+
+// JQLAutocomplete returns JQL autocomplete data, cached per server
+func (c *Client) JQLAutocomplete() (*JQLAutocompleteData, error) {
+	var data JQLAutocompleteData
+	if ok, _ := cache.Load(c.config.Server, "jql_autocomplete", fieldCacheTTL, &data); ok {
+		return &data, nil
+	}
+
+	req, err := c.NewRequest("GET", "rest/api/2/jql/autocompletedata", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req, &data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JQL autocomplete data: %w", newAPIError(resp, err))
+	}
+
+	_ = cache.Save(c.config.Server, "jql_autocomplete", data)
+	return &data, nil
+}
+
+// JQLSuggestions returns values for a JQL field that start with prefix
+func (c *Client) JQLSuggestions(fieldName, prefix string) ([]JQLSuggestion, error) {
+	params := url.Values{}
+	params.Set("fieldName", fieldName)
+	params.Set("fieldValue", prefix)
+
+	req, err := c.NewRequest("GET", "rest/api/2/jql/autocompletedata/suggestions?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result struct {
+		Results []JQLSuggestion `json:"results"`
+	}
+	resp, err := c.Do(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JQL suggestions: %w", newAPIError(resp, err))
+	}
+	return result.Results, nil
+}
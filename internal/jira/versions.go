@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// GetVersions returns every version of a project, oldest first
+func (c *Client) GetVersions(project string) ([]jira.Version, error) {
+	req, err := c.NewRequest("GET", fmt.Sprintf("rest/api/2/project/%s/versions", url.PathEscape(project)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var versions []jira.Version
+	resp, err := c.Do(req, &versions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions: %w", newAPIError(resp, err))
+	}
+	return versions, nil
+}
+
+// CreateVersionOptions holds the optional settings of a new version. Dates are YYYY-MM-DD.
+type CreateVersionOptions struct {
+	Description string
+	StartDate   string
+	ReleaseDate string
+}
+
+// CreateVersion creates a version in a project
+func (c *Client) CreateVersion(project, name string, opts *CreateVersionOptions) (*jira.Version, error) {
+	p, resp, err := c.Project.Get(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", newAPIError(resp, err))
+	}
+	projectID, err := strconv.Atoi(p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected project ID %q", p.ID)
+	}
+
+	v := &jira.Version{Name: name, ProjectID: projectID}
+	if opts != nil {
+		v.Description = opts.Description
+		v.StartDate = opts.StartDate
+		v.ReleaseDate = opts.ReleaseDate
+	}
+	created, resp, err := c.Version.Create(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create version: %w", newAPIError(resp, err))
+	}
+	return created, nil
+}
+
+// UpdateVersion sets fields of a version, e.g. "released" or "archived"
+func (c *Client) UpdateVersion(id string, fields map[string]interface{}) (*jira.Version, error) {
+	req, err := c.NewRequest("PUT", fmt.Sprintf("rest/api/2/version/%s", url.PathEscape(id)), fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var v jira.Version
+	resp, err := c.Do(req, &v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update version: %w", newAPIError(resp, err))
+	}
+	return &v, nil
+}
+
+// ChangeFixVersions adds and removes fix versions of an issue by version ID, keeping the others
+func (c *Client) ChangeFixVersions(key string, add, remove []string) error {
+	var ops []map[string]interface{}
+	for _, id := range remove {
+		ops = append(ops, map[string]interface{}{"remove": map[string]string{"id": id}})
+	}
+	for _, id := range add {
+		ops = append(ops, map[string]interface{}{"add": map[string]string{"id": id}})
+	}
+	data := map[string]interface{}{"update": map[string]interface{}{"fixVersions": ops}}
+	resp, err := c.Issue.UpdateIssue(key, data)
+	if err != nil {
+		return fmt.Errorf("failed to update fix versions of %s: %w", key, newAPIError(resp, err))
+	}
+	return nil
+}
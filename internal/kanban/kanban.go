@@ -0,0 +1,502 @@
+package kanban
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// Card is an issue shown on the board
+type Card struct {
+	Key      string
+	Summary  string
+	Assignee string
+	Type     string
+	Status   string
+}
+
+// Column is a board column and the cards currently in it, in rank order
+type Column struct {
+	Name  string
+	Cards []Card
+}
+
+// Filter is a quick filter that can be toggled on and off
+type Filter struct {
+	ID   int
+	Name string
+}
+
+// Source loads the board and moves cards between its columns
+type Source interface {
+	// Load returns the board's columns holding the cards that match every given quick filter
+	Load(filters []int) ([]Column, error)
+	// Move moves a card into the column with the given index
+	Move(card Card, to int) error
+}
+
+// View is a full-screen Kanban board
+type View struct {
+	Title   string
+	Filters []Filter
+	Source  Source
+	// Refresh is how often the board is reloaded; zero disables it
+	Refresh time.Duration
+	// URL returns the link shown in a card's details
+	URL func(key string) string
+}
+
+const (
+	cardHeight     = 4 // key, summary, assignee and a blank line
+	minColumnWidth = 20
+	helpText       = "←↓↑→ select  shift+←→ move card  enter details  1-9 quick filters  0 clear  r refresh  q quit"
+)
+
+var (
+	styleDefault  = tcell.StyleDefault
+	styleBold     = styleDefault.Bold(true)
+	styleDim      = styleDefault.Dim(true)
+	styleSelected = styleDefault.Reverse(true)
+)
+
+// loadEvent carries the result of a load or move back to the event loop
+type loadEvent struct {
+	tcell.EventTime
+	columns []Column
+	moved   string
+	err     error
+}
+
+// tickEvent asks the event loop to refresh the board
+type tickEvent struct {
+	tcell.EventTime
+}
+
+type board struct {
+	view    *View
+	screen  tcell.Screen
+	columns []Column
+	active  []bool
+
+	col, row int
+	offsets  []int
+	first    int // leftmost visible column
+
+	busy    bool
+	status  string
+	details bool
+	keep    string // key of the card to keep selected after a reload
+}
+
+// Run shows the board until the user quits
+func (v *View) Run() error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return fmt.Errorf("failed to open terminal: %w", err)
+	}
+	if err := screen.Init(); err != nil {
+		return fmt.Errorf("failed to open terminal: %w", err)
+	}
+	defer screen.Fini()
+
+	b := &board{view: v, screen: screen, active: make([]bool, len(v.Filters))}
+	b.load()
+
+	if v.Refresh > 0 {
+		ticker := time.NewTicker(v.Refresh)
+		done := make(chan struct{})
+		defer func() {
+			ticker.Stop()
+			close(done)
+		}()
+		go func() {
+			for {
+				select {
+				case <-ticker.C:
+					ev := &tickEvent{}
+					ev.SetEventNow()
+					_ = screen.PostEvent(ev)
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		b.draw()
+		switch ev := screen.PollEvent().(type) {
+		case nil:
+			return nil
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventKey:
+			if b.handleKey(ev) {
+				return nil
+			}
+		case *tickEvent:
+			if !b.busy {
+				b.load()
+			}
+		case *loadEvent:
+			b.finish(ev)
+		}
+	}
+}
+
+// load reloads the board in the background
+func (b *board) load() {
+	var filters []int
+	for i, on := range b.active {
+		if on {
+			filters = append(filters, b.view.Filters[i].ID)
+		}
+	}
+
+	b.busy = true
+	b.status = "Loading…"
+	go func() {
+		columns, err := b.view.Source.Load(filters)
+		ev := &loadEvent{columns: columns, err: err}
+		ev.SetEventNow()
+		_ = b.screen.PostEvent(ev)
+	}()
+}
+
+// move moves the selected card by delta columns in the background
+func (b *board) move(delta int) {
+	selected := b.selected()
+	to := b.col + delta
+	if selected == nil || b.busy || to < 0 || to >= len(b.columns) {
+		return
+	}
+	card := *selected
+
+	b.busy = true
+	b.keep = card.Key
+	b.status = fmt.Sprintf("Moving %s to %s…", card.Key, b.columns[to].Name)
+	go func() {
+		err := b.view.Source.Move(card, to)
+		ev := &loadEvent{moved: card.Key, err: err}
+		ev.SetEventNow()
+		_ = b.screen.PostEvent(ev)
+	}()
+}
+
+// finish applies the result of a load or move
+func (b *board) finish(ev *loadEvent) {
+	b.busy = false
+	if ev.err != nil {
+		b.status = ev.err.Error()
+		return
+	}
+	if ev.moved != "" {
+		b.load()
+		return
+	}
+
+	if card := b.selected(); card != nil && b.keep == "" {
+		b.keep = card.Key
+	}
+	b.columns = ev.columns
+	if len(b.offsets) != len(b.columns) {
+		b.offsets = make([]int, len(b.columns))
+	}
+	if b.keep != "" {
+		for c, column := range b.columns {
+			for r, card := range column.Cards {
+				if card.Key == b.keep {
+					b.col, b.row = c, r
+				}
+			}
+		}
+		b.keep = ""
+	}
+	b.clamp()
+	b.status = "Updated " + time.Now().Format("15:04:05")
+}
+
+// handleKey applies a key press and reports whether the user quit
+func (b *board) handleKey(ev *tcell.EventKey) bool {
+	if b.details {
+		b.details = false
+		return false
+	}
+
+	shift := ev.Modifiers()&tcell.ModShift != 0
+	switch ev.Key() {
+	case tcell.KeyCtrlC, tcell.KeyEscape:
+		return true
+	case tcell.KeyLeft:
+		if shift {
+			b.move(-1)
+		} else {
+			b.col--
+		}
+	case tcell.KeyRight:
+		if shift {
+			b.move(1)
+		} else {
+			b.col++
+		}
+	case tcell.KeyUp:
+		b.row--
+	case tcell.KeyDown:
+		b.row++
+	case tcell.KeyHome:
+		b.row = 0
+	case tcell.KeyEnd:
+		if card := b.selected(); card != nil {
+			b.row = len(b.columns[b.col].Cards) - 1
+		}
+	case tcell.KeyEnter:
+		b.details = b.selected() != nil
+	case tcell.KeyRune:
+		switch r := ev.Rune(); {
+		case r == 'q':
+			return true
+		case r == 'h':
+			b.col--
+		case r == 'l':
+			b.col++
+		case r == 'k':
+			b.row--
+		case r == 'j':
+			b.row++
+		case r == 'H' || r == '<':
+			b.move(-1)
+		case r == 'L' || r == '>':
+			b.move(1)
+		case r == 'r':
+			if !b.busy {
+				b.load()
+			}
+		case r == '0':
+			if !b.busy {
+				clear(b.active)
+				b.load()
+			}
+		case r >= '1' && r <= '9':
+			if i := int(r - '1'); i < len(b.active) && !b.busy {
+				b.active[i] = !b.active[i]
+				b.load()
+			}
+		}
+	}
+	b.clamp()
+	return false
+}
+
+// clamp keeps the selection on an existing card, preferring the same row in a new column
+func (b *board) clamp() {
+	if len(b.columns) == 0 {
+		b.col, b.row = 0, 0
+		return
+	}
+	b.col = max(0, min(b.col, len(b.columns)-1))
+	b.row = max(0, min(b.row, len(b.columns[b.col].Cards)-1))
+}
+
+// selected returns the selected card, or nil if the selected column is empty
+func (b *board) selected() *Card {
+	if b.col >= len(b.columns) || b.row >= len(b.columns[b.col].Cards) {
+		return nil
+	}
+	return &b.columns[b.col].Cards[b.row]
+}
+
+func (b *board) draw() {
+	s := b.screen
+	s.Clear()
+	defer s.Show()
+	width, height := s.Size()
+
+	x := drawText(s, 0, 0, width, b.view.Title, styleBold)
+	if b.status != "" {
+		status := truncate(b.status, max(0, width-x-2))
+		drawText(s, width-runewidth.StringWidth(status), 0, width, status, styleDim)
+	}
+
+	y := 1
+	if len(b.view.Filters) > 0 {
+		x := drawText(s, 0, y, width, "Quick filters: ", styleDim)
+		for i, f := range b.view.Filters {
+			style := styleDefault
+			if b.active[i] {
+				style = styleSelected
+			}
+			label := f.Name
+			if i < 9 {
+				label = fmt.Sprintf("%d %s", i+1, f.Name)
+			}
+			x += drawText(s, x, y, width-x, label, style)
+			x += drawText(s, x, y, width-x, "  ", styleDefault)
+		}
+		y++
+	}
+	y++
+	drawText(s, 0, height-1, width, helpText, styleDim)
+
+	if len(b.columns) == 0 {
+		return
+	}
+
+	// Show as many columns as fit at their minimum width, scrolled to keep the selection visible
+	visible := max(1, min(len(b.columns), (width+1)/(minColumnWidth+1)))
+	b.first = max(min(b.first, b.col), b.col-visible+1)
+	colWidth := (width - (visible - 1)) / visible
+
+	top := y + 2
+	rows := max(1, (height-top)/cardHeight)
+	for i := 0; i < visible && b.first+i < len(b.columns); i++ {
+		c := b.first + i
+		column := b.columns[c]
+		x := i * (colWidth + 1)
+		if i > 0 {
+			for row := y; row < height-1; row++ {
+				s.SetContent(x-1, row, '│', nil, styleDim)
+			}
+			s.SetContent(x-1, y+1, '┼', nil, styleDim)
+		}
+
+		header := fmt.Sprintf("%s (%d)", strings.ToUpper(column.Name), len(column.Cards))
+		drawText(s, x, y, colWidth, header, styleBold)
+		for dx := 0; dx < colWidth; dx++ {
+			s.SetContent(x+dx, y+1, '─', nil, styleDim)
+		}
+
+		offset := b.offsets[c]
+		if c == b.col {
+			offset = max(min(offset, b.row), b.row-rows+1)
+			b.offsets[c] = offset
+		}
+		for r := offset; r < len(column.Cards) && r < offset+rows; r++ {
+			selected := c == b.col && r == b.row
+			drawCard(s, x, top+(r-offset)*cardHeight, colWidth, column.Cards[r], selected)
+		}
+	}
+
+	if b.details {
+		b.drawDetails(width, height)
+	}
+}
+
+func drawCard(s tcell.Screen, x, y, width int, card Card, selected bool) {
+	key, summary, assignee := styleBold, styleDefault, styleDim
+	if selected {
+		key, summary, assignee = styleSelected.Bold(true), styleSelected, styleSelected
+		for dy := 0; dy < cardHeight-1; dy++ {
+			for dx := 0; dx < width; dx++ {
+				s.SetContent(x+dx, y+dy, ' ', nil, styleSelected)
+			}
+		}
+	}
+
+	n := drawText(s, x, y, width, card.Key, key)
+	if card.Type != "" {
+		drawText(s, x+n+1, y, width-n-1, card.Type, assignee)
+	}
+	drawText(s, x, y+1, width, card.Summary, summary)
+	drawText(s, x, y+2, width, orUnassigned(card.Assignee), assignee)
+}
+
+func (b *board) drawDetails(width, height int) {
+	card := b.selected()
+	if card == nil {
+		return
+	}
+
+	boxWidth := min(width-4, 80)
+	inner := boxWidth - 4
+	lines := []string{card.Key, ""}
+	lines = append(lines, wrap(card.Summary, inner)...)
+	lines = append(lines, "",
+		"Status:    "+card.Status,
+		"Type:      "+card.Type,
+		"Assignee:  "+orUnassigned(card.Assignee))
+	if b.view.URL != nil {
+		lines = append(lines, "", b.view.URL(card.Key))
+	}
+
+	boxHeight := len(lines) + 2
+	x0, y0 := (width-boxWidth)/2, max(0, (height-boxHeight)/2)
+	for dy := 0; dy < boxHeight; dy++ {
+		for dx := 0; dx < boxWidth; dx++ {
+			r := ' '
+			switch {
+			case dy == 0 && dx == 0:
+				r = '┌'
+			case dy == 0 && dx == boxWidth-1:
+				r = '┐'
+			case dy == boxHeight-1 && dx == 0:
+				r = '└'
+			case dy == boxHeight-1 && dx == boxWidth-1:
+				r = '┘'
+			case dy == 0 || dy == boxHeight-1:
+				r = '─'
+			case dx == 0 || dx == boxWidth-1:
+				r = '│'
+			}
+			b.screen.SetContent(x0+dx, y0+dy, r, nil, styleDefault)
+		}
+	}
+	for i, line := range lines {
+		style := styleDefault
+		if i == 0 {
+			style = styleBold
+		}
+		drawText(b.screen, x0+2, y0+1+i, inner, line, style)
+	}
+}
+
+// drawText writes text truncated to width cells and returns the number of cells used
+func drawText(s tcell.Screen, x, y, width int, text string, style tcell.Style) int {
+	used := 0
+	for _, r := range truncate(text, width) {
+		s.SetContent(x+used, y, r, nil, style)
+		used += runewidth.RuneWidth(r)
+	}
+	return used
+}
+
+// truncate shortens text to fit in width cells, marking the cut with an ellipsis
+func truncate(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(text) <= width {
+		return text
+	}
+	return runewidth.Truncate(text, width, "…")
+}
+
+// wrap breaks text into lines of at most width cells at word boundaries
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case runewidth.StringWidth(line)+1+runewidth.StringWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func orUnassigned(name string) string {
+	if name == "" {
+		return "Unassigned"
+	}
+	return name
+}
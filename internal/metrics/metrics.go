@@ -0,0 +1,308 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+// Fields are the fields needed from each issue; fetch them with the changelog expanded
+var Fields = []string{"summary", "issuetype", "status", "created"}
+
+// Workflow stages
+const (
+	ToDo       = "todo"
+	InProgress = "in_progress"
+	Done       = "done"
+)
+
+// changelogTime is the timestamp format used in issue changelogs
+const changelogTime = "2006-01-02T15:04:05.000-0700"
+
+// Stages maps lower-case status names to workflow stages
+type Stages map[string]string
+
+// NewStages builds stages from status categories (status name to "new", "indeterminate"
+// or "done"). A non-empty inProgress or done list replaces the statuses of that stage.
+func NewStages(categories map[string]string, inProgress, done []string) Stages {
+	s := make(Stages, len(categories))
+	for name, category := range categories {
+		switch category {
+		case "indeterminate":
+			s[strings.ToLower(name)] = InProgress
+		case "done":
+			s[strings.ToLower(name)] = Done
+		}
+	}
+	s.replace(InProgress, inProgress)
+	s.replace(Done, done)
+	return s
+}
+
+func (s Stages) replace(stage string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	for name, st := range s {
+		if st == stage {
+			delete(s, name)
+		}
+	}
+	for _, name := range names {
+		s[strings.ToLower(name)] = stage
+	}
+}
+
+// Of returns the stage of a status
+func (s Stages) Of(status string) string {
+	if stage, ok := s[strings.ToLower(status)]; ok {
+		return stage
+	}
+	return ToDo
+}
+
+// IssueTimes records when an issue was created, first started and finished
+type IssueTimes struct {
+	Key     string     `json:"key"`
+	Summary string     `json:"summary"`
+	Type    string     `json:"type"`
+	Created time.Time  `json:"created"`
+	Started *time.Time `json:"started,omitempty"`
+	// Done is when the issue last moved into a done status; nil unless it is done now
+	Done *time.Time `json:"done,omitempty"`
+}
+
+// LeadTime is the time from creation to done
+func (t IssueTimes) LeadTime() (time.Duration, bool) {
+	if t.Done == nil {
+		return 0, false
+	}
+	return t.Done.Sub(t.Created), true
+}
+
+// CycleTime is the time from first starting work to done
+func (t IssueTimes) CycleTime() (time.Duration, bool) {
+	if t.Done == nil || t.Started == nil {
+		return 0, false
+	}
+	return t.Done.Sub(*t.Started), true
+}
+
+// Times replays an issue's status history
+func Times(issue jira.RawIssue, stages Stages) IssueTimes {
+	t := IssueTimes{
+		Key:     issue.Key,
+		Summary: jira.FormatFieldValue(issue.Fields["summary"]),
+		Type:    jira.FormatFieldValue(issue.Fields["issuetype"]),
+	}
+	t.Created, _ = time.Parse(changelogTime, jira.FormatFieldValue(issue.Fields["created"]))
+
+	type transition struct {
+		at       time.Time
+		from, to string
+	}
+	var transitions []transition
+	if issue.Changelog != nil {
+		for _, h := range issue.Changelog.Histories {
+			at, err := time.Parse(changelogTime, h.Created)
+			if err != nil {
+				continue
+			}
+			for _, item := range h.Items {
+				if item.Field == "status" {
+					transitions = append(transitions, transition{at, item.FromString, item.ToString})
+				}
+			}
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool { return transitions[i].at.Before(transitions[j].at) })
+
+	var done time.Time
+	for _, tr := range transitions {
+		to := stages.Of(tr.to)
+		if to == InProgress && t.Started == nil {
+			started := tr.at
+			t.Started = &started
+		}
+		if to == Done && stages.Of(tr.from) != Done {
+			done = tr.at
+		}
+	}
+	if stages.Of(jira.FormatFieldValue(issue.Fields["status"])) == Done {
+		if done.IsZero() {
+			// Created straight into a done status
+			done = t.Created
+		}
+		t.Done = &done
+	}
+	return t
+}
+
+// Summary holds percentiles and the mean of a set of durations
+type Summary struct {
+	Count int           `json:"count"`
+	P50   time.Duration `json:"p50"`
+	P85   time.Duration `json:"p85"`
+	P95   time.Duration `json:"p95"`
+	Mean  time.Duration `json:"mean"`
+}
+
+// Summarize computes percentiles using the nearest-rank method
+func Summarize(durations []time.Duration) Summary {
+	s := Summary{Count: len(durations)}
+	if len(durations) == 0 {
+		return s
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	s.Mean = total / time.Duration(len(sorted))
+	s.P50 = Percentile(sorted, 50)
+	s.P85 = Percentile(sorted, 85)
+	s.P95 = Percentile(sorted, 95)
+	return s
+}
+
+// Percentile returns the p-th percentile of sorted durations
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(0, min(rank, len(sorted))-1)]
+}
+
+// Week is the number of issues finished in the week starting on Start (a Monday)
+type Week struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+// Throughput counts finished issues per week from the week containing since up to until
+func Throughput(issues []IssueTimes, since, until time.Time) []Week {
+	var weeks []Week
+	for start := weekStart(since); !start.After(until); start = start.AddDate(0, 0, 7) {
+		weeks = append(weeks, Week{Start: start})
+	}
+	for _, issue := range issues {
+		if issue.Done == nil || issue.Done.Before(since) || issue.Done.After(until) {
+			continue
+		}
+		i := int(weekStart(*issue.Done).Sub(weekStart(since)).Hours()/24+0.5) / 7
+		if i >= 0 && i < len(weeks) {
+			weeks[i].Count++
+		}
+	}
+	return weeks
+}
+
+// weekStart returns midnight local time on the Monday of t's week
+func weekStart(t time.Time) time.Time {
+	t = t.Local()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.Local)
+}
+
+// Bucket is a histogram bar covering durations from Min up to (not including) Max.
+// Max is zero for the last, open-ended bucket.
+type Bucket struct {
+	Label string
+	Min   time.Duration
+	Max   time.Duration
+	Count int
+}
+
+// histogramDays are the bucket boundaries in days, growing roughly like Fibonacci
+var histogramDays = []int{1, 2, 3, 5, 8, 13, 21, 34}
+
+// Histogram counts durations in day-sized buckets
+func Histogram(durations []time.Duration) []Bucket {
+	day := 24 * time.Hour
+	buckets := []Bucket{{Label: "< 1d", Max: day}}
+	for i := 1; i < len(histogramDays); i++ {
+		lo, hi := histogramDays[i-1], histogramDays[i]
+		buckets = append(buckets, Bucket{
+			Label: fmt.Sprintf("%d-%dd", lo, hi),
+			Min:   time.Duration(lo) * day,
+			Max:   time.Duration(hi) * day,
+		})
+	}
+	last := histogramDays[len(histogramDays)-1]
+	buckets = append(buckets, Bucket{Label: fmt.Sprintf("%dd+", last), Min: time.Duration(last) * day})
+
+	for _, d := range durations {
+		for i := range buckets {
+			if d >= buckets[i].Min && (buckets[i].Max == 0 || d < buckets[i].Max) {
+				buckets[i].Count++
+				break
+			}
+		}
+	}
+	return buckets
+}
+
+// SprintVelocity is the work committed to and completed in a closed sprint
+type SprintVelocity struct {
+	Sprint    string  `json:"sprint"`
+	Committed float64 `json:"committed"`
+	Completed float64 `json:"completed"`
+}
+
+// Result is everything the metrics command reports
+type Result struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// Unit is "points" or "issues" for velocity
+	Unit       string           `json:"unit,omitempty"`
+	Velocity   []SprintVelocity `json:"velocity,omitempty"`
+	Issues     []IssueTimes     `json:"issues"`
+	LeadTime   Summary          `json:"lead_time"`
+	CycleTime  Summary          `json:"cycle_time"`
+	Throughput []Week           `json:"throughput"`
+}
+
+// NewResult computes flow metrics for the issues finished between since and until
+func NewResult(times []IssueTimes, since, until time.Time) *Result {
+	r := &Result{Since: since, Until: until}
+	for _, t := range times {
+		if t.Done != nil && !t.Done.Before(since) && !t.Done.After(until) {
+			r.Issues = append(r.Issues, t)
+		}
+	}
+	sort.Slice(r.Issues, func(i, j int) bool { return r.Issues[i].Done.Before(*r.Issues[j].Done) })
+
+	r.LeadTime = Summarize(r.LeadTimes())
+	r.CycleTime = Summarize(r.CycleTimes())
+	r.Throughput = Throughput(r.Issues, since, until)
+	return r
+}
+
+// CycleTimes returns the cycle time of every issue that has one
+func (r *Result) CycleTimes() []time.Duration {
+	var out []time.Duration
+	for _, t := range r.Issues {
+		if d, ok := t.CycleTime(); ok {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// LeadTimes returns the lead time of every finished issue
+func (r *Result) LeadTimes() []time.Duration {
+	var out []time.Duration
+	for _, t := range r.Issues {
+		if d, ok := t.LeadTime(); ok {
+			out = append(out, d)
+		}
+	}
+	return out
+}
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSV tables
+const (
+	DataIssues     = "issues"
+	DataThroughput = "throughput"
+	DataVelocity   = "velocity"
+
+	barWidth = 40
+)
+
+// DataTables lists the tables available as CSV
+var DataTables = []string{DataIssues, DataThroughput, DataVelocity}
+
+// TableOptions selects the optional parts of the table output
+type TableOptions struct {
+	Histogram bool
+	Issues    bool
+}
+
+// WriteTable prints the metrics as aligned tables
+func WriteTable(out io.Writer, r *Result, opts TableOptions) error {
+	var b strings.Builder
+
+	if len(r.Velocity) > 0 {
+		fmt.Fprintf(&b, "Velocity (%s, last %d sprints)\n", r.Unit, len(r.Velocity))
+		width := len("Average")
+		for _, v := range r.Velocity {
+			width = max(width, len(v.Sprint))
+		}
+		fmt.Fprintf(&b, "%-*s  %9s  %9s\n", width, "Sprint", "Committed", "Completed")
+		var committed, completed float64
+		for _, v := range r.Velocity {
+			fmt.Fprintf(&b, "%-*s  %9s  %9s\n", width, v.Sprint, formatNumber(v.Committed), formatNumber(v.Completed))
+			committed += v.Committed
+			completed += v.Completed
+		}
+		n := float64(len(r.Velocity))
+		fmt.Fprintf(&b, "%-*s  %9.1f  %9.1f\n\n", width, "Average", committed/n, completed/n)
+	}
+
+	fmt.Fprintf(&b, "Flow (%d issues finished %s - %s)\n", len(r.Issues),
+		r.Since.Local().Format("2006-01-02"), r.Until.Local().Format("2006-01-02"))
+	fmt.Fprintf(&b, "%-11s %6s %8s %8s %8s %8s\n", "", "Issues", "p50", "p85", "p95", "Mean")
+	for _, row := range []struct {
+		name string
+		s    Summary
+	}{{"Lead time", r.LeadTime}, {"Cycle time", r.CycleTime}} {
+		fmt.Fprintf(&b, "%-11s %6d %8s %8s %8s %8s\n", row.name, row.s.Count,
+			formatDays(row.s.P50), formatDays(row.s.P85), formatDays(row.s.P95), formatDays(row.s.Mean))
+	}
+
+	b.WriteString("\nThroughput per week\n")
+	top := 0
+	for _, w := range r.Throughput {
+		top = max(top, w.Count)
+	}
+	for _, w := range r.Throughput {
+		fmt.Fprintf(&b, "%s  %s %d\n", w.Start.Format("2006-01-02"), bar(w.Count, top), w.Count)
+	}
+
+	if opts.Histogram {
+		writeHistogram(&b, "Lead time", Histogram(r.LeadTimes()))
+		writeHistogram(&b, "Cycle time", Histogram(r.CycleTimes()))
+	}
+
+	if opts.Issues && len(r.Issues) > 0 {
+		b.WriteString("\nIssues\n")
+		fmt.Fprintf(&b, "%-12s %-10s %8s %8s  %s\n", "Key", "Done", "Lead", "Cycle", "Summary")
+		for _, t := range r.Issues {
+			lead, _ := t.LeadTime()
+			cycle := "-"
+			if d, ok := t.CycleTime(); ok {
+				cycle = formatDays(d)
+			}
+			fmt.Fprintf(&b, "%-12s %-10s %8s %8s  %s\n", t.Key, t.Done.Local().Format("2006-01-02"), formatDays(lead), cycle, t.Summary)
+		}
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func writeHistogram(b *strings.Builder, name string, buckets []Bucket) {
+	top := 0
+	for _, bucket := range buckets {
+		top = max(top, bucket.Count)
+	}
+	fmt.Fprintf(b, "\n%s histogram\n", name)
+	for _, bucket := range buckets {
+		fmt.Fprintf(b, "%-7s %s %d\n", bucket.Label, bar(bucket.Count, top), bucket.Count)
+	}
+}
+
+// WriteCSV writes one of the DataTables as CSV
+func WriteCSV(out io.Writer, r *Result, table string) error {
+	var rows [][]string
+	switch table {
+	case DataIssues:
+		rows = append(rows, []string{"key", "type", "summary", "created", "started", "done", "lead_days", "cycle_days"})
+		for _, t := range r.Issues {
+			lead, _ := t.LeadTime()
+			started, cycle := "", ""
+			if d, ok := t.CycleTime(); ok {
+				started = t.Started.Format(time.RFC3339)
+				cycle = formatFloat(d.Hours() / 24)
+			}
+			rows = append(rows, []string{t.Key, t.Type, t.Summary, t.Created.Format(time.RFC3339), started,
+				t.Done.Format(time.RFC3339), formatFloat(lead.Hours() / 24), cycle})
+		}
+	case DataThroughput:
+		rows = append(rows, []string{"week", "issues"})
+		for _, w := range r.Throughput {
+			rows = append(rows, []string{w.Start.Format("2006-01-02"), strconv.Itoa(w.Count)})
+		}
+	case DataVelocity:
+		rows = append(rows, []string{"sprint", "committed", "completed", "unit"})
+		for _, v := range r.Velocity {
+			rows = append(rows, []string{v.Sprint, formatNumber(v.Committed), formatNumber(v.Completed), r.Unit})
+		}
+	default:
+		return fmt.Errorf("unknown table %q (use %s)", table, strings.Join(DataTables, ", "))
+	}
+
+	w := csv.NewWriter(out)
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// bar draws count as a horizontal bar scaled so that top fills barWidth
+func bar(count, top int) string {
+	if top == 0 {
+		return ""
+	}
+	n := count * barWidth / top
+	if n == 0 && count > 0 {
+		n = 1
+	}
+	return strings.Repeat("█", n)
+}
+
+func formatDays(d time.Duration) string {
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
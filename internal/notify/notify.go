@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	dbusDest   = "org.freedesktop.Notifications"
+	dbusPath   = "/org/freedesktop/Notifications"
+	dbusMethod = "org.freedesktop.Notifications.Notify"
+	appName    = "jiractl"
+	// expireDefault lets the notification server decide how long to show it
+	expireDefault = int32(-1)
+)
+
+// Notification is a message about new issues
+type Notification struct {
+	Query string   `json:"query"`
+	Title string   `json:"title"`
+	Body  string   `json:"body"`
+	URL   string   `json:"url,omitempty"`
+	Keys  []string `json:"keys"`
+}
+
+// Send delivers n with command, or as a freedesktop notification over D-Bus when command is empty
+func Send(n Notification, command string) error {
+	if command != "" {
+		return runCommand(n, command)
+	}
+	return sendDBus(n)
+}
+
+// sendDBus shows a notification through the session bus
+func sendDBus(n Notification) error {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return fmt.Errorf("failed to connect to D-Bus session bus (set notify command instead): %w", err)
+	}
+	defer conn.Close()
+
+	body := n.Body
+	if n.URL != "" {
+		body += "\n" + n.URL
+	}
+
+	obj := conn.Object(dbusDest, dbus.ObjectPath(dbusPath))
+	call := obj.Call(dbusMethod, 0, appName, uint32(0), "", n.Title, body, []string{}, map[string]dbus.Variant{}, expireDefault)
+	if call.Err != nil {
+		return fmt.Errorf("failed to send notification: %w", call.Err)
+	}
+	return nil
+}
+
+// runCommand runs a shell command with the notification as JSON on stdin and
+// its title, body and URL in JIRACTL_NOTIFY_* environment variables
+func runCommand(n Notification, command string) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	c.Stdin = bytes.NewReader(append(data, '\n'))
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(),
+		"JIRACTL_NOTIFY_QUERY="+n.Query,
+		"JIRACTL_NOTIFY_TITLE="+n.Title,
+		"JIRACTL_NOTIFY_BODY="+n.Body,
+		"JIRACTL_NOTIFY_URL="+n.URL,
+	)
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("notify command failed: %w", err)
+	}
+	return nil
+}
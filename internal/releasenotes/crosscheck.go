@@ -0,0 +1,51 @@
+package releasenotes
+
+import (
+	"github.com/eugenetaranov/jiractl/internal/git"
+)
+
+// Mismatch is a commit referencing issues that are not in the version
+type Mismatch struct {
+	Commit git.Commit
+	Keys   []string
+}
+
+// CrossCheck compares the version's issues with the issue keys in commit messages. It
+// returns the commits that only reference issues outside the version, the commits that
+// reference no issue at all, and the issues no commit references. Keys are found with
+// git.MessageIssueKeys, independent of the configured branch key pattern.
+func CrossCheck(issues []Issue, commits []git.Commit) (outside []Mismatch, unreferenced []git.Commit, uncommitted []Issue) {
+	inVersion := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inVersion[issue.Key] = true
+	}
+
+	committed := make(map[string]bool)
+	for _, c := range commits {
+		keys := git.MessageIssueKeys(c.Message)
+		if len(keys) == 0 {
+			unreferenced = append(unreferenced, c)
+			continue
+		}
+		var others []string
+		matched := false
+		for _, key := range keys {
+			if inVersion[key] {
+				committed[key] = true
+				matched = true
+			} else {
+				others = append(others, key)
+			}
+		}
+		if !matched {
+			outside = append(outside, Mismatch{Commit: c, Keys: others})
+		}
+	}
+
+	for _, issue := range issues {
+		if !committed[issue.Key] {
+			uncommitted = append(uncommitted, issue)
+		}
+	}
+	return outside, unreferenced, uncommitted
+}
@@ -0,0 +1,137 @@
+package releasenotes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+// Fields are the fields needed from each issue
+var Fields = []string{"summary", "issuetype", "status", "labels", "assignee", "resolution"}
+
+// Ways to group issues
+const (
+	GroupByType  = "type"
+	GroupByLabel = "label"
+)
+
+// otherGroup holds issues without a label when grouping by label
+const otherGroup = "Other"
+
+// Issue is an issue listed in the release notes
+type Issue struct {
+	Key        string   `json:"key"`
+	Summary    string   `json:"summary"`
+	Type       string   `json:"type"`
+	Status     string   `json:"status"`
+	Resolution string   `json:"resolution,omitempty"`
+	Assignee   string   `json:"assignee,omitempty"`
+	Labels     []string `json:"labels,omitempty"`
+	URL        string   `json:"url"`
+}
+
+// Group is a heading in the release notes with its issues
+type Group struct {
+	Name   string
+	Issues []Issue
+}
+
+// Version describes the release
+type Version struct {
+	Name        string
+	Description string
+	ReleaseDate string
+	Released    bool
+}
+
+// Notes is the data passed to release notes templates
+type Notes struct {
+	Project string
+	Version Version
+	Groups  []Group
+	// Issues is every issue in the version, ordered by key
+	Issues []Issue
+}
+
+// NewIssue reads an issue from search results
+func NewIssue(issue jira.RawIssue, server string) Issue {
+	i := Issue{
+		Key:        issue.Key,
+		Summary:    jira.FormatFieldValue(issue.Fields["summary"]),
+		Type:       jira.FormatFieldValue(issue.Fields["issuetype"]),
+		Status:     jira.FormatFieldValue(issue.Fields["status"]),
+		Resolution: jira.FormatFieldValue(issue.Fields["resolution"]),
+		Assignee:   jira.FormatFieldValue(issue.Fields["assignee"]),
+		URL:        fmt.Sprintf("%s/browse/%s", strings.TrimRight(server, "/"), issue.Key),
+	}
+	if labels, ok := issue.Fields["labels"].([]interface{}); ok {
+		for _, l := range labels {
+			if s, ok := l.(string); ok {
+				i.Labels = append(i.Labels, s)
+			}
+		}
+	}
+	return i
+}
+
+// New groups issues by type or label. Groups are ordered by name, except that with
+// label grouping, issues without labels come last under "Other"; issues with several
+// labels are listed under each.
+func New(project string, version Version, issues []Issue, groupBy string) (*Notes, error) {
+	if groupBy != GroupByType && groupBy != GroupByLabel {
+		return nil, fmt.Errorf("unsupported grouping %q (use %s or %s)", groupBy, GroupByType, GroupByLabel)
+	}
+
+	sorted := append([]Issue(nil), issues...)
+	sort.SliceStable(sorted, func(i, j int) bool { return lessKey(sorted[i].Key, sorted[j].Key) })
+
+	groups := make(map[string][]Issue)
+	for _, issue := range sorted {
+		names := []string{issue.Type}
+		if groupBy == GroupByLabel {
+			names = issue.Labels
+			if len(names) == 0 {
+				names = []string{otherGroup}
+			}
+		}
+		for _, name := range names {
+			groups[name] = append(groups[name], issue)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		if groupBy != GroupByLabel || name != otherGroup {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := groups[otherGroup]; ok && groupBy == GroupByLabel {
+		names = append(names, otherGroup)
+	}
+
+	n := &Notes{Project: project, Version: version, Issues: sorted}
+	for _, name := range names {
+		n.Groups = append(n.Groups, Group{Name: name, Issues: groups[name]})
+	}
+	return n, nil
+}
+
+// lessKey orders issue keys by project, then numerically, so PROJ-9 comes before PROJ-10
+func lessKey(a, b string) bool {
+	pa, na := splitKey(a)
+	pb, nb := splitKey(b)
+	if pa != pb {
+		return pa < pb
+	}
+	return na < nb
+}
+
+func splitKey(key string) (string, int) {
+	project, num, _ := strings.Cut(key, "-")
+	n, _ := strconv.Atoi(num)
+	return project, n
+}
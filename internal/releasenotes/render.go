@@ -0,0 +1,102 @@
+package releasenotes
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatText     = "text"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatMarkdown, FormatHTML, FormatText}
+
+var defaultTemplates = map[string]string{
+	FormatMarkdown: `# {{.Project}} {{.Version.Name}}{{with .Version.ReleaseDate}} ({{.}}){{end}}
+{{with .Version.Description}}
+{{.}}
+{{end}}{{range .Groups}}
+## {{.Name}}
+
+{{range .Issues}}- [{{.Key}}]({{.URL}}) {{.Summary}}
+{{end}}{{end}}`,
+
+	FormatHTML: `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Project}} {{.Version.Name}} release notes</title>
+</head>
+<body>
+<h1>{{.Project}} {{.Version.Name}}{{with .Version.ReleaseDate}} ({{.}}){{end}}</h1>
+{{with .Version.Description}}<p>{{.}}</p>
+{{end}}{{range .Groups}}<h2>{{.Name}}</h2>
+<ul>
+{{range .Issues}}<li><a href="{{.URL}}">{{.Key}}</a> {{.Summary}}</li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`,
+
+	FormatText: `{{.Project}} {{.Version.Name}}{{with .Version.ReleaseDate}} ({{.}}){{end}}
+{{with .Version.Description}}
+{{.}}
+{{end}}{{range .Groups}}
+{{.Name}}
+{{range .Issues}}  {{.Key}}  {{.Summary}}
+{{end}}{{end}}`,
+}
+
+// funcs are available in templates in addition to the built-in functions
+var funcs = map[string]interface{}{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// DefaultTemplate returns the built-in template for a format, as a starting point for
+// a user template
+func DefaultTemplate(format string) (string, error) {
+	tmpl, ok := defaultTemplates[format]
+	if !ok {
+		return "", fmt.Errorf("unsupported format %q (use %s)", format, strings.Join(Formats, ", "))
+	}
+	return tmpl, nil
+}
+
+// Write renders the notes with a template, or with the format's built-in template when
+// tmpl is empty. HTML templates escape issue text automatically.
+func Write(out io.Writer, n *Notes, format, tmpl string) error {
+	if tmpl == "" {
+		var err error
+		if tmpl, err = DefaultTemplate(format); err != nil {
+			return err
+		}
+	}
+
+	var t interface {
+		Execute(io.Writer, interface{}) error
+	}
+	var err error
+	switch format {
+	case FormatHTML:
+		t, err = htmltemplate.New("notes").Funcs(funcs).Parse(tmpl)
+	case FormatMarkdown, FormatText:
+		t, err = template.New("notes").Funcs(funcs).Parse(tmpl)
+	default:
+		return fmt.Errorf("unsupported format %q (use %s)", format, strings.Join(Formats, ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("invalid release notes template: %w", err)
+	}
+	if err := t.Execute(out, n); err != nil {
+		return fmt.Errorf("failed to render release notes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+package reorder
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// Item is a row of the list
+type Item struct {
+	Key  string
+	Line string
+}
+
+// Move ranks Keys, in order, directly before Before or directly after After
+type Move struct {
+	Keys   []string
+	Before string
+	After  string
+}
+
+const helpText = "↑↓ select  shift+↑↓ or K J move  t top  b bottom  s save  q quit"
+
+var (
+	styleDefault  = tcell.StyleDefault
+	styleBold     = styleDefault.Bold(true)
+	styleDim      = styleDefault.Dim(true)
+	styleSelected = styleDefault.Reverse(true)
+)
+
+type list struct {
+	screen   tcell.Screen
+	title    string
+	items    []Item
+	moved    map[string]bool
+	selected int
+	offset   int
+	status   string
+	quitting bool
+}
+
+// Run shows items full screen and lets the user reorder them. It returns the new
+// order and whether the user chose to save it.
+func Run(title string, items []Item) ([]Item, bool, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open terminal: %w", err)
+	}
+	if err := screen.Init(); err != nil {
+		return nil, false, fmt.Errorf("failed to open terminal: %w", err)
+	}
+	defer screen.Fini()
+
+	l := &list{
+		screen: screen,
+		title:  title,
+		items:  append([]Item(nil), items...),
+		moved:  make(map[string]bool),
+	}
+	for {
+		l.draw()
+		switch ev := screen.PollEvent().(type) {
+		case nil:
+			return nil, false, nil
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventKey:
+			if done, save := l.handleKey(ev); done {
+				return l.items, save, nil
+			}
+		}
+	}
+}
+
+// handleKey applies a key press and reports whether the list is closed and should be saved
+func (l *list) handleKey(ev *tcell.EventKey) (done, save bool) {
+	quitting := l.quitting
+	l.quitting = false
+	l.status = ""
+
+	shift := ev.Modifiers()&tcell.ModShift != 0
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		return true, false
+	case tcell.KeyEscape:
+		return l.quit(quitting)
+	case tcell.KeyUp:
+		if shift {
+			l.move(l.selected - 1)
+		} else {
+			l.selected--
+		}
+	case tcell.KeyDown:
+		if shift {
+			l.move(l.selected + 1)
+		} else {
+			l.selected++
+		}
+	case tcell.KeyPgUp:
+		l.selected -= l.pageSize()
+	case tcell.KeyPgDn:
+		l.selected += l.pageSize()
+	case tcell.KeyHome:
+		l.selected = 0
+	case tcell.KeyEnd:
+		l.selected = len(l.items) - 1
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'q':
+			return l.quit(quitting)
+		case 's':
+			return true, len(l.moved) > 0
+		case 'k':
+			l.selected--
+		case 'j':
+			l.selected++
+		case 'K':
+			l.move(l.selected - 1)
+		case 'J':
+			l.move(l.selected + 1)
+		case 't':
+			l.move(0)
+		case 'b':
+			l.move(len(l.items) - 1)
+		case 'g':
+			l.selected = 0
+		case 'G':
+			l.selected = len(l.items) - 1
+		}
+	}
+	l.selected = max(0, min(l.selected, len(l.items)-1))
+	return false, false
+}
+
+// quit closes the list, asking for a second key press when there are unsaved changes
+func (l *list) quit(confirmed bool) (done, save bool) {
+	if len(l.moved) == 0 || confirmed {
+		return true, false
+	}
+	l.quitting = true
+	l.status = "Unsaved changes: press q again to discard them, or s to save"
+	return false, false
+}
+
+// move moves the selected item to position to
+func (l *list) move(to int) {
+	from := l.selected
+	if to < 0 || to >= len(l.items) || to == from {
+		return
+	}
+	item := l.items[from]
+	if from < to {
+		copy(l.items[from:to], l.items[from+1:to+1])
+	} else {
+		copy(l.items[to+1:from+1], l.items[to:from])
+	}
+	l.items[to] = item
+	l.moved[item.Key] = true
+	l.selected = to
+}
+
+// pageSize is the number of rows available for items
+func (l *list) pageSize() int {
+	_, height := l.screen.Size()
+	return max(1, height-3)
+}
+
+func (l *list) draw() {
+	s := l.screen
+	s.Clear()
+	defer s.Show()
+	width, height := s.Size()
+
+	title := l.title
+	if len(l.moved) > 0 {
+		title += fmt.Sprintf("  (%d moved, unsaved)", len(l.moved))
+	}
+	drawText(s, 0, 0, width, title, styleBold)
+
+	rows := l.pageSize()
+	l.offset = max(min(l.offset, l.selected), l.selected-rows+1)
+	numWidth := len(fmt.Sprint(len(l.items)))
+	for i := l.offset; i < len(l.items) && i < l.offset+rows; i++ {
+		item := l.items[i]
+		style := styleDefault
+		if i == l.selected {
+			style = styleSelected
+			for x := 0; x < width; x++ {
+				s.SetContent(x, 2+i-l.offset, ' ', nil, style)
+			}
+		}
+		marker := " "
+		if l.moved[item.Key] {
+			marker = "*"
+		}
+		drawText(s, 0, 2+i-l.offset, width, fmt.Sprintf("%s%*d. %s", marker, numWidth, i+1, item.Line), style)
+	}
+
+	footer, style := helpText, styleDim
+	if l.status != "" {
+		footer, style = l.status, styleBold
+	}
+	drawText(s, 0, height-1, width, footer, style)
+}
+
+// drawText writes text truncated to width cells
+func drawText(s tcell.Screen, x, y, width int, text string, style tcell.Style) {
+	if runewidth.StringWidth(text) > width {
+		text = runewidth.Truncate(text, width, "…")
+	}
+	for _, r := range text {
+		s.SetContent(x, y, r, nil, style)
+		x += runewidth.RuneWidth(r)
+	}
+}
+
+// Plan returns the rank moves that turn the original order into the reordered one.
+// Issues on the longest run that kept its relative order stay put; every other issue is
+// ranked after its new predecessor, with consecutive issues moved together.
+func Plan(original, reordered []string) []Move {
+	index := make(map[string]int, len(original))
+	for i, key := range original {
+		index[key] = i
+	}
+	positions := make([]int, len(reordered))
+	for i, key := range reordered {
+		positions[i] = index[key]
+	}
+	stays := longestIncreasing(positions)
+
+	var moves []Move
+	for i := 0; i < len(reordered); {
+		if stays[i] {
+			i++
+			continue
+		}
+		j := i
+		for j < len(reordered) && !stays[j] {
+			j++
+		}
+		m := Move{Keys: append([]string(nil), reordered[i:j]...)}
+		if i > 0 {
+			m.After = reordered[i-1]
+		} else if j < len(reordered) {
+			m.Before = reordered[j]
+		}
+		if m.After != "" || m.Before != "" {
+			moves = append(moves, m)
+		}
+		i = j
+	}
+	return moves
+}
+
+// longestIncreasing marks the elements of one longest strictly increasing subsequence
+func longestIncreasing(values []int) []bool {
+	// tails[k] is the index of the smallest tail of an increasing run of length k+1
+	var tails []int
+	prev := make([]int, len(values))
+	for i, v := range values {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if values[tails[mid]] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		prev[i] = -1
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	keep := make([]bool, len(values))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			keep[i] = true
+		}
+	}
+	return keep
+}
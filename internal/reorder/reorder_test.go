@@ -0,0 +1,111 @@
+package reorder
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		original  string
+		reordered string
+		want      []Move
+	}{
+		{
+			name:      "unchanged",
+			original:  "A B C D",
+			reordered: "A B C D",
+			want:      nil,
+		},
+		{
+			name:      "empty",
+			original:  "",
+			reordered: "",
+			want:      nil,
+		},
+		{
+			name:      "moved to top",
+			original:  "A B C D",
+			reordered: "D A B C",
+			want:      []Move{{Keys: []string{"D"}, Before: "A"}},
+		},
+		{
+			name:      "moved to bottom",
+			original:  "A B C D",
+			reordered: "B C D A",
+			want:      []Move{{Keys: []string{"A"}, After: "D"}},
+		},
+		{
+			name:      "swapped",
+			original:  "A B C D",
+			reordered: "A C B D",
+			want:      []Move{{Keys: []string{"C"}, After: "A"}},
+		},
+		{
+			name:      "block moved to top",
+			original:  "A B C D E",
+			reordered: "D E A B C",
+			want:      []Move{{Keys: []string{"D", "E"}, Before: "A"}},
+		},
+		{
+			name:      "block moved to middle",
+			original:  "A B C D E F",
+			reordered: "A D E B C F",
+			want:      []Move{{Keys: []string{"D", "E"}, After: "A"}},
+		},
+		{
+			name:      "reversed",
+			original:  "A B C D",
+			reordered: "D C B A",
+			want:      []Move{{Keys: []string{"D", "C", "B"}, Before: "A"}},
+		},
+		{
+			name:      "several moves",
+			original:  "A B C D E F G H",
+			reordered: "H A C B D F E G",
+			want: []Move{
+				{Keys: []string{"H"}, Before: "A"},
+				{Keys: []string{"C"}, After: "A"},
+				{Keys: []string{"F"}, After: "D"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original, reordered := strings.Fields(tt.original), strings.Fields(tt.reordered)
+			got := Plan(original, reordered)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Plan() = %+v, want %+v", got, tt.want)
+			}
+			if replayed := replay(t, original, got); !slices.Equal(replayed, reordered) {
+				t.Errorf("replaying %+v gives %v, want %v", got, replayed, reordered)
+			}
+		})
+	}
+}
+
+// replay applies moves to order the way Jira ranks issues
+func replay(t *testing.T, order []string, moves []Move) []string {
+	t.Helper()
+	order = slices.Clone(order)
+	for _, m := range moves {
+		order = slices.DeleteFunc(order, func(key string) bool { return slices.Contains(m.Keys, key) })
+		anchor := m.Before
+		if anchor == "" {
+			anchor = m.After
+		}
+		i := slices.Index(order, anchor)
+		if i < 0 {
+			t.Fatalf("move %+v ranks against %q, which is not in %v", m, anchor, order)
+		}
+		if m.After != "" {
+			i++
+		}
+		order = slices.Insert(order, i, m.Keys...)
+	}
+	return order
+}
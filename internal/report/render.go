@@ -0,0 +1,297 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	FormatText     = "text"
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+
+	chartHeight = 10
+	chartWidth  = 60
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatText, FormatMarkdown, FormatJSON}
+
+// eighths are the block characters for partially filled chart cells
+var eighths = []rune(" ▁▂▃▄▅▆▇█")
+
+// Write renders the report in the given format
+func Write(out io.Writer, r *Report, format string) error {
+	switch format {
+	case FormatText:
+		return writeText(out, r)
+	case FormatMarkdown:
+		return writeMarkdown(out, r)
+	case FormatJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+	return CheckFormat(format)
+}
+
+// CheckFormat returns an error if format is not one of Formats
+func CheckFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported format %q (use %s)", format, strings.Join(Formats, ", "))
+}
+
+// section is a group of issues listed in the report
+type section struct {
+	title  string
+	issues []Issue
+}
+
+func (r *Report) sections() []section {
+	var completed, incomplete, added, removed []Issue
+	for _, issue := range r.Issues {
+		switch {
+		case issue.RemovedAt != nil:
+			removed = append(removed, issue)
+		case issue.CompletedAt != nil:
+			completed = append(completed, issue)
+		default:
+			incomplete = append(incomplete, issue)
+		}
+		if issue.AddedAt != nil {
+			added = append(added, issue)
+		}
+	}
+	return []section{
+		{"Completed", completed},
+		{"Not completed", incomplete},
+		{"Added after the sprint started", added},
+		{"Removed from the sprint", removed},
+	}
+}
+
+// totalsRow is a labelled line of the summary table
+type totalsRow struct {
+	name string
+	t    Totals
+}
+
+// totals returns the summary rows in display order
+func (r *Report) totals() []totalsRow {
+	return []totalsRow{
+		{"Committed", r.Committed},
+		{"Added", r.Added},
+		{"Removed", r.Removed},
+		{"Completed", r.Completed},
+		{"Not completed", r.Incomplete},
+	}
+}
+
+// dates describes when the sprint ran
+func (r *Report) dates() string {
+	s := r.Sprint
+	dates := formatDate(s.StartDate) + " - " + formatDate(s.EndDate)
+	if s.CompleteDate != nil {
+		dates += ", completed " + formatDate(s.CompleteDate)
+	}
+	return dates
+}
+
+// note describes when an added or removed issue changed
+func note(issue Issue) string {
+	var notes []string
+	if issue.AddedAt != nil {
+		notes = append(notes, "added "+formatDate(issue.AddedAt))
+	}
+	if issue.RemovedAt != nil {
+		notes = append(notes, "removed "+formatDate(issue.RemovedAt))
+	}
+	return strings.Join(notes, ", ")
+}
+
+func writeText(out io.Writer, r *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)\n", r.Sprint.Name, r.Sprint.State)
+	fmt.Fprintf(&b, "─────────────────────────────────────────────────────────\n")
+	fmt.Fprintf(&b, "Dates:       %s\n", r.dates())
+	if r.Sprint.Goal != "" {
+		fmt.Fprintf(&b, "Goal:        %s\n", r.Sprint.Goal)
+	}
+
+	b.WriteString("\n")
+	if r.Unit == "points" {
+		fmt.Fprintf(&b, "%-15s %6s %8s\n", "", "Issues", "Points")
+	}
+	for _, row := range r.totals() {
+		if r.Unit == "points" {
+			fmt.Fprintf(&b, "%-15s %6d %8s\n", row.name, row.t.Issues, formatNumber(row.t.Points))
+		} else {
+			fmt.Fprintf(&b, "%-15s %6d\n", row.name, row.t.Issues)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nBurndown (%s remaining, · ideal)\n", r.Unit)
+	for _, line := range Chart(r.Burndown) {
+		b.WriteString(line + "\n")
+	}
+
+	for _, s := range r.sections() {
+		if len(s.issues) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s (%d)\n", s.title, len(s.issues))
+		for _, issue := range s.issues {
+			line := fmt.Sprintf("  %-12s", issue.Key)
+			if r.Unit == "points" {
+				line += fmt.Sprintf(" %5s", formatNumber(issue.Points))
+			}
+			line += "  " + issue.Summary
+			if n := note(issue); n != "" {
+				line += "  (" + n + ")"
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func writeMarkdown(out io.Writer, r *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", r.Sprint.Name)
+	fmt.Fprintf(&b, "- **State:** %s\n", r.Sprint.State)
+	fmt.Fprintf(&b, "- **Dates:** %s\n", r.dates())
+	if r.Sprint.Goal != "" {
+		fmt.Fprintf(&b, "- **Goal:** %s\n", markdownCell(r.Sprint.Goal))
+	}
+
+	b.WriteString("\n")
+	if r.Unit == "points" {
+		b.WriteString("| | Issues | Points |\n|---|---:|---:|\n")
+	} else {
+		b.WriteString("| | Issues |\n|---|---:|\n")
+	}
+	for _, row := range r.totals() {
+		if r.Unit == "points" {
+			fmt.Fprintf(&b, "| %s | %d | %s |\n", row.name, row.t.Issues, formatNumber(row.t.Points))
+		} else {
+			fmt.Fprintf(&b, "| %s | %d |\n", row.name, row.t.Issues)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n### Burndown\n\n```text\n")
+	for _, line := range Chart(r.Burndown) {
+		b.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&b, "```\n_%s remaining; · marks the ideal line_\n", strings.ToUpper(r.Unit[:1])+r.Unit[1:])
+
+	for _, s := range r.sections() {
+		if len(s.issues) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s (%d)\n\n", s.title, len(s.issues))
+		if r.Unit == "points" {
+			b.WriteString("| Key | Type | Summary | Points | Notes |\n|---|---|---|---:|---|\n")
+		} else {
+			b.WriteString("| Key | Type | Summary | Notes |\n|---|---|---|---|\n")
+		}
+		for _, issue := range s.issues {
+			if r.Unit == "points" {
+				fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", issue.Key, markdownCell(issue.Type),
+					markdownCell(issue.Summary), formatNumber(issue.Points), note(issue))
+			} else {
+				fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", issue.Key, markdownCell(issue.Type),
+					markdownCell(issue.Summary), note(issue))
+			}
+		}
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// Chart draws the burndown as a bar per sample with the ideal line dotted over it
+func Chart(points []BurndownPoint) []string {
+	if len(points) == 0 {
+		return nil
+	}
+
+	top := 0.0
+	for _, p := range points {
+		top = max(top, p.Ideal)
+		if p.Remaining != nil {
+			top = max(top, *p.Remaining)
+		}
+	}
+	if top == 0 {
+		top = 1
+	}
+
+	colWidth := max(1, min(3, chartWidth/len(points)))
+	labelWidth := len(formatNumber(top))
+
+	var lines []string
+	for row := chartHeight; row >= 1; row-- {
+		hi := top * float64(row) / chartHeight
+		lo := top * float64(row-1) / chartHeight
+
+		label := ""
+		if row == chartHeight {
+			label = formatNumber(top)
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "%*s ┤", labelWidth, label)
+		for _, p := range points {
+			cell := ' '
+			if p.Remaining != nil {
+				switch rem := *p.Remaining; {
+				case rem >= hi:
+					cell = '█'
+				case rem > lo:
+					cell = eighths[max(1, int((rem-lo)/(hi-lo)*8))]
+				}
+			}
+			if cell == ' ' && p.Ideal > lo && p.Ideal <= hi {
+				cell = '·'
+			}
+			b.WriteString(strings.Repeat(string(cell), colWidth))
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+
+	axisWidth := colWidth * len(points)
+	lines = append(lines, fmt.Sprintf("%*s └%s", labelWidth, "0", strings.Repeat("─", axisWidth)))
+
+	first := points[0].Time.Local().Format("01-02")
+	last := points[len(points)-1].Time.Local().Format("01-02")
+	gap := max(1, axisWidth-runewidth.StringWidth(first)-runewidth.StringWidth(last))
+	lines = append(lines, fmt.Sprintf("%*s  %s%s%s", labelWidth, "", first, strings.Repeat(" ", gap), last))
+	return lines
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "?"
+	}
+	return t.Local().Format("2006-01-02")
+}
+
+// markdownCell escapes text for use in a Markdown table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
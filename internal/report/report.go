@@ -0,0 +1,350 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+// Fields are the fields the report needs from each issue, besides the estimation field
+var Fields = []string{"summary", "issuetype", "status", "created"}
+
+// changelogTime is the timestamp format used in issue changelogs
+const changelogTime = "2006-01-02T15:04:05.000-0700"
+
+// Options describe how to interpret a sprint's issues
+type Options struct {
+	// DoneStatuses holds the IDs of the statuses that count as complete (the board's last column)
+	DoneStatuses map[string]bool
+	// PointsField is the estimation field ID and PointsName its changelog name; without
+	// them the report counts issues instead of points
+	PointsField string
+	PointsName  string
+	// Now is the end of the report for sprints that are still active
+	Now time.Time
+}
+
+// Totals is a number of issues and the story points they carry
+type Totals struct {
+	Issues int     `json:"issues"`
+	Points float64 `json:"points"`
+}
+
+func (t *Totals) add(points float64) {
+	t.Issues++
+	t.Points += points
+}
+
+// Issue is the outcome of one issue that was part of the sprint at some point
+type Issue struct {
+	Key       string  `json:"key"`
+	Summary   string  `json:"summary"`
+	Type      string  `json:"type"`
+	Status    string  `json:"status"`
+	Points    float64 `json:"points"`
+	Committed bool    `json:"committed"`
+	// AddedAt is set for issues added after the sprint started
+	AddedAt *time.Time `json:"added_at,omitempty"`
+	// RemovedAt is set for issues taken out of the sprint before it ended
+	RemovedAt *time.Time `json:"removed_at,omitempty"`
+	// CompletedAt is set for issues that were done when the sprint ended
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// BurndownPoint is the remaining work at the end of a day of the sprint.
+// Remaining is nil for days that have not happened yet.
+type BurndownPoint struct {
+	Time      time.Time `json:"time"`
+	Remaining *float64  `json:"remaining,omitempty"`
+	Ideal     float64   `json:"ideal"`
+}
+
+// Report summarises a sprint: what was committed, what changed and what got done
+type Report struct {
+	Sprint jira.Sprint `json:"sprint"`
+	// Unit is "points" when the board estimates with a field, otherwise "issues"
+	Unit       string          `json:"unit"`
+	Committed  Totals          `json:"committed"`
+	Added      Totals          `json:"added"`
+	Removed    Totals          `json:"removed"`
+	Completed  Totals          `json:"completed"`
+	Incomplete Totals          `json:"incomplete"`
+	Issues     []Issue         `json:"issues"`
+	Burndown   []BurndownPoint `json:"burndown"`
+}
+
+// change is a field value taking effect at a point in time
+type change struct {
+	at       time.Time
+	from, to string
+}
+
+// timeline is the history of one field of an issue
+type timeline struct {
+	created time.Time
+	initial string
+	changes []change
+}
+
+// at returns the field's value at t, or "" before the issue existed
+func (tl timeline) at(t time.Time) string {
+	if t.Before(tl.created) {
+		return ""
+	}
+	v := tl.initial
+	for _, c := range tl.changes {
+		if c.at.After(t) {
+			break
+		}
+		v = c.to
+	}
+	return v
+}
+
+// newTimeline rebuilds a field's history from the changelog and its current value
+func newTimeline(created time.Time, histories []gojira.ChangelogHistory, field, current string, raw bool) timeline {
+	tl := timeline{created: created, initial: current}
+	for _, h := range histories {
+		at, err := time.Parse(changelogTime, h.Created)
+		if err != nil {
+			continue
+		}
+		for _, item := range h.Items {
+			if !strings.EqualFold(item.Field, field) {
+				continue
+			}
+			c := change{at: at, from: item.FromString, to: item.ToString}
+			if raw {
+				c.from, c.to = itemValue(item.From), itemValue(item.To)
+			}
+			tl.changes = append(tl.changes, c)
+		}
+	}
+	sort.SliceStable(tl.changes, func(i, j int) bool { return tl.changes[i].at.Before(tl.changes[j].at) })
+	if len(tl.changes) > 0 {
+		tl.initial = tl.changes[0].from
+	}
+	return tl
+}
+
+func itemValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// inSprint reports whether a Sprint field value (a comma-separated list of IDs) includes id
+func inSprint(value, id string) bool {
+	for _, s := range strings.Split(value, ",") {
+		if strings.TrimSpace(s) == id {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePoints(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return v
+}
+
+// history is the part of an issue's changelog the report replays
+type history struct {
+	issue  Issue
+	sprint timeline
+	status timeline
+	points timeline
+}
+
+// Build computes the report for a started sprint. Issues are fetched with their changelog;
+// current holds the issues in the sprint now, and others may hold issues that were removed.
+func Build(sprint jira.Sprint, current, others []jira.RawIssue, opts Options) (*Report, error) {
+	if sprint.StartDate == nil {
+		return nil, fmt.Errorf("sprint %s has not started", sprint.Name)
+	}
+	start := *sprint.StartDate
+	end := opts.Now
+	if sprint.CompleteDate != nil {
+		end = *sprint.CompleteDate
+	}
+
+	r := &Report{Sprint: sprint, Unit: "issues"}
+	if opts.PointsField != "" {
+		r.Unit = "points"
+	}
+
+	id := strconv.Itoa(sprint.ID)
+	var members []history
+	for i, issue := range append(append([]jira.RawIssue(nil), current...), others...) {
+		h := newHistory(issue, id, i < len(current), opts)
+
+		atStart := inSprint(h.sprint.at(start), id)
+		atEnd := inSprint(h.sprint.at(end), id)
+		var added, removed *time.Time
+		for _, c := range h.sprint.changes {
+			if !c.at.After(start) || c.at.After(end) {
+				continue
+			}
+			at := c.at
+			switch was, is := inSprint(c.from, id), inSprint(c.to, id); {
+			case !was && is && !atStart && added == nil:
+				added = &at
+			case was && !is:
+				removed = &at
+			}
+		}
+		// Issues created straight into the sprint have no Sprint change
+		if !atStart && added == nil && atEnd && h.sprint.created.After(start) {
+			created := h.sprint.created
+			added = &created
+		}
+		if !atStart && added == nil {
+			continue
+		}
+
+		h.issue.Committed = atStart
+		h.issue.AddedAt = added
+		if atStart {
+			r.Committed.add(h.pointsAt(start, r.Unit))
+		} else {
+			r.Added.add(h.pointsAt(*added, r.Unit))
+		}
+
+		if !atEnd {
+			if removed == nil {
+				removed = &end
+			}
+			h.issue.RemovedAt = removed
+			h.issue.Points = h.pointsAt(*removed, r.Unit)
+			r.Removed.add(h.issue.Points)
+		} else {
+			h.issue.Points = h.pointsAt(end, r.Unit)
+			if opts.DoneStatuses[h.status.at(end)] {
+				h.issue.CompletedAt = h.doneSince(end, opts.DoneStatuses)
+				r.Completed.add(h.issue.Points)
+			} else {
+				r.Incomplete.add(h.issue.Points)
+			}
+		}
+		members = append(members, h)
+		r.Issues = append(r.Issues, h.issue)
+	}
+
+	r.Burndown = burndown(sprint, start, end, members, opts.DoneStatuses, r.Unit)
+	return r, nil
+}
+
+func newHistory(issue jira.RawIssue, sprintID string, inCurrent bool, opts Options) history {
+	var histories []gojira.ChangelogHistory
+	if issue.Changelog != nil {
+		histories = issue.Changelog.Histories
+	}
+	created, _ := time.Parse(changelogTime, jira.FormatFieldValue(issue.Fields["created"]))
+
+	membership := ""
+	if inCurrent {
+		membership = sprintID
+	}
+	status, _ := issue.Fields["status"].(map[string]interface{})
+	statusID, _ := status["id"].(string)
+
+	h := history{
+		issue: Issue{
+			Key:     issue.Key,
+			Summary: jira.FormatFieldValue(issue.Fields["summary"]),
+			Type:    jira.FormatFieldValue(issue.Fields["issuetype"]),
+			Status:  jira.FormatFieldValue(issue.Fields["status"]),
+		},
+		sprint: newTimeline(created, histories, "Sprint", membership, true),
+		status: newTimeline(created, histories, "status", statusID, true),
+	}
+	if opts.PointsField != "" {
+		h.points = newTimeline(created, histories, opts.PointsName, jira.FormatFieldValue(issue.Fields[opts.PointsField]), false)
+	}
+	return h
+}
+
+// pointsAt returns the issue's estimate at t, or 1 when counting issues
+func (h history) pointsAt(t time.Time, unit string) float64 {
+	if unit == "issues" {
+		return 1
+	}
+	return parsePoints(h.points.at(t))
+}
+
+// doneSince returns when the issue last entered a done status before t
+func (h history) doneSince(t time.Time, done map[string]bool) *time.Time {
+	since := h.status.created
+	for _, c := range h.status.changes {
+		if c.at.After(t) {
+			break
+		}
+		if done[c.to] && !done[c.from] {
+			since = c.at
+		}
+	}
+	return &since
+}
+
+// remainingAt returns the work left in the sprint at t
+func remainingAt(t time.Time, members []history, sprintID string, done map[string]bool, unit string) float64 {
+	remaining := 0.0
+	for _, h := range members {
+		if inSprint(h.sprint.at(t), sprintID) && !done[h.status.at(t)] {
+			remaining += h.pointsAt(t, unit)
+		}
+	}
+	return remaining
+}
+
+// burndown samples the remaining work at the sprint start and at the end of each day,
+// with the ideal line running from the starting scope to zero at the planned end
+func burndown(sprint jira.Sprint, start, end time.Time, members []history, done map[string]bool, unit string) []BurndownPoint {
+	id := strconv.Itoa(sprint.ID)
+	planned := end
+	if sprint.EndDate != nil && sprint.EndDate.After(start) {
+		planned = *sprint.EndDate
+	}
+	last := planned
+	if end.After(last) {
+		last = end
+	}
+
+	initial := remainingAt(start, members, id, done, unit)
+	ideal := func(t time.Time) float64 {
+		if !t.Before(planned) {
+			return 0
+		}
+		return initial * (1 - float64(t.Sub(start))/float64(planned.Sub(start)))
+	}
+
+	points := []BurndownPoint{{Time: start, Remaining: &initial, Ideal: initial}}
+	local := start.Local()
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	reached := false
+	for !day.After(last) {
+		day = day.AddDate(0, 0, 1)
+		t := day
+		if t.After(last) {
+			t = last
+		}
+		p := BurndownPoint{Time: t, Ideal: ideal(t)}
+		// Days after the end of an active sprint are left empty
+		if !reached {
+			sample := t
+			if !sample.Before(end) {
+				sample, reached = end, true
+			}
+			remaining := remainingAt(sample, members, id, done, unit)
+			p.Remaining = &remaining
+		}
+		points = append(points, p)
+	}
+	return points
+}
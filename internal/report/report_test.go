@@ -0,0 +1,346 @@
+package report
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+const (
+	sprintID     = 7
+	statusToDo   = "1"
+	statusReview = "2"
+	statusDone   = "3"
+	pointsField  = "customfield_10016"
+	pointsName   = "Story Points"
+)
+
+// day returns a time on the given day of March 2026; the test sprints start on the 2nd
+func day(d, hour int) time.Time {
+	return time.Date(2026, 3, d, hour, 0, 0, 0, time.Local)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// testChange is one changelog entry of a test issue
+type testChange struct {
+	at       time.Time
+	field    string
+	from, to string
+}
+
+func sprintChange(at time.Time, from, to string) testChange {
+	return testChange{at: at, field: "Sprint", from: from, to: to}
+}
+
+func statusChange(at time.Time, from, to string) testChange {
+	return testChange{at: at, field: "status", from: from, to: to}
+}
+
+func pointsChange(at time.Time, from, to string) testChange {
+	return testChange{at: at, field: pointsName, from: from, to: to}
+}
+
+// newIssue builds an issue as the search returns it with its changelog
+func newIssue(key string, created time.Time, status string, points float64, changes ...testChange) jira.RawIssue {
+	var histories []gojira.ChangelogHistory
+	for _, c := range changes {
+		item := gojira.ChangelogItems{Field: c.field, FromString: c.from, ToString: c.to}
+		if c.field != pointsName {
+			item.From, item.To = c.from, c.to
+		}
+		histories = append(histories, gojira.ChangelogHistory{
+			Created: c.at.Format(changelogTime),
+			Items:   []gojira.ChangelogItems{item},
+		})
+	}
+	return jira.RawIssue{
+		Key: key,
+		Fields: map[string]interface{}{
+			"summary":   "Issue " + key,
+			"issuetype": map[string]interface{}{"name": "Story"},
+			"status":    map[string]interface{}{"id": status, "name": "Status " + status},
+			"created":   created.Format(changelogTime),
+			pointsField: points,
+		},
+		Changelog: &gojira.Changelog{Histories: histories},
+	}
+}
+
+func testOptions(now time.Time) Options {
+	return Options{
+		DoneStatuses: map[string]bool{statusDone: true},
+		PointsField:  pointsField,
+		PointsName:   pointsName,
+		Now:          now,
+	}
+}
+
+// closedSprint ran from the 2nd at 10:00 until it was closed on the 4th at 16:00
+func closedSprint() jira.Sprint {
+	return jira.Sprint{
+		ID:           sprintID,
+		Name:         "Sprint 7",
+		StartDate:    ptr(day(2, 10)),
+		EndDate:      ptr(day(4, 10)),
+		CompleteDate: ptr(day(4, 16)),
+	}
+}
+
+func TestBuildScope(t *testing.T) {
+	start, end := day(2, 10), day(4, 16)
+	tests := []struct {
+		name    string
+		issue   jira.RawIssue
+		current bool
+		want    *Issue
+		totals  map[string]Totals
+	}{
+		{
+			name:    "committed and done",
+			issue:   newIssue("P-1", day(1, 9), statusDone, 5, pointsChange(day(3, 8), "3", "5"), statusChange(day(3, 12), statusToDo, statusDone)),
+			current: true,
+			want:    &Issue{Key: "P-1", Points: 5, Committed: true, CompletedAt: ptr(day(3, 12))},
+			totals:  map[string]Totals{"committed": {1, 3}, "completed": {1, 5}},
+		},
+		{
+			name:    "committed but not in the last column",
+			issue:   newIssue("P-2", day(1, 9), statusReview, 3),
+			current: true,
+			want:    &Issue{Key: "P-2", Points: 3, Committed: true},
+			totals:  map[string]Totals{"committed": {1, 3}, "incomplete": {1, 3}},
+		},
+		{
+			name:    "planned before the start",
+			issue:   newIssue("P-3", day(1, 9), statusToDo, 1, sprintChange(day(2, 9), "", "7")),
+			current: true,
+			want:    &Issue{Key: "P-3", Points: 1, Committed: true},
+			totals:  map[string]Totals{"committed": {1, 1}, "incomplete": {1, 1}},
+		},
+		{
+			name:    "added after the start",
+			issue:   newIssue("P-4", day(1, 9), statusToDo, 2, sprintChange(day(3, 9), "", "7")),
+			current: true,
+			want:    &Issue{Key: "P-4", Points: 2, AddedAt: ptr(day(3, 9))},
+			totals:  map[string]Totals{"added": {1, 2}, "incomplete": {1, 2}},
+		},
+		{
+			name:    "moved in from another sprint",
+			issue:   newIssue("P-5", day(1, 9), statusDone, 2, sprintChange(day(3, 9), "6", "6,7"), statusChange(day(4, 9), statusToDo, statusDone)),
+			current: true,
+			want:    &Issue{Key: "P-5", Points: 2, AddedAt: ptr(day(3, 9)), CompletedAt: ptr(day(4, 9))},
+			totals:  map[string]Totals{"added": {1, 2}, "completed": {1, 2}},
+		},
+		{
+			name:    "created into the sprint",
+			issue:   newIssue("P-6", day(3, 11), statusToDo, 1),
+			current: true,
+			want:    &Issue{Key: "P-6", Points: 1, AddedAt: ptr(day(3, 11))},
+			totals:  map[string]Totals{"added": {1, 1}, "incomplete": {1, 1}},
+		},
+		{
+			name:   "removed during the sprint",
+			issue:  newIssue("P-7", day(1, 9), statusToDo, 8, sprintChange(day(1, 12), "", "7"), sprintChange(day(3, 14), "7", "")),
+			want:   &Issue{Key: "P-7", Points: 8, Committed: true, RemovedAt: ptr(day(3, 14))},
+			totals: map[string]Totals{"committed": {1, 8}, "removed": {1, 8}},
+		},
+		{
+			name:    "added and removed again",
+			issue:   newIssue("P-8", day(1, 9), statusToDo, 3, sprintChange(day(2, 12), "", "7"), sprintChange(day(3, 12), "7", "")),
+			current: false,
+			want:    &Issue{Key: "P-8", Points: 3, AddedAt: ptr(day(2, 12)), RemovedAt: ptr(day(3, 12))},
+			totals:  map[string]Totals{"added": {1, 3}, "removed": {1, 3}},
+		},
+		{
+			name:    "added after the sprint was closed",
+			issue:   newIssue("P-9", day(1, 9), statusToDo, 3, sprintChange(day(5, 9), "", "7")),
+			current: true,
+		},
+		{
+			name:  "never in the sprint",
+			issue: newIssue("P-10", day(1, 9), statusToDo, 3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var current, others []jira.RawIssue
+			if tt.current {
+				current = append(current, tt.issue)
+			} else {
+				others = append(others, tt.issue)
+			}
+			r, err := Build(closedSprint(), current, others, testOptions(day(10, 0)))
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			if tt.want == nil {
+				if len(r.Issues) != 0 {
+					t.Errorf("Build() issues = %+v, want none", r.Issues)
+				}
+			} else {
+				if len(r.Issues) != 1 {
+					t.Fatalf("Build() issues = %+v, want one", r.Issues)
+				}
+				got := r.Issues[0]
+				got.Summary, got.Type, got.Status = "", "", ""
+				if !reflect.DeepEqual(got, *tt.want) {
+					t.Errorf("Build() issue = %s, want %s", describe(got), describe(*tt.want))
+				}
+			}
+
+			gotTotals := map[string]Totals{
+				"committed": r.Committed, "added": r.Added, "removed": r.Removed,
+				"completed": r.Completed, "incomplete": r.Incomplete,
+			}
+			for name, got := range gotTotals {
+				if want := tt.totals[name]; got != want {
+					t.Errorf("%s = %+v, want %+v", name, got, want)
+				}
+			}
+			if !r.Burndown[0].Time.Equal(start) || !r.Burndown[len(r.Burndown)-1].Time.Equal(end) {
+				t.Errorf("burndown runs from %v to %v, want %v to %v", r.Burndown[0].Time, r.Burndown[len(r.Burndown)-1].Time, start, end)
+			}
+		})
+	}
+}
+
+func describe(i Issue) string {
+	s := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.Format("Jan 2 15:04")
+	}
+	return i.Key + " points=" + jira.FormatFieldValue(i.Points) + " committed=" + jira.FormatFieldValue(i.Committed) +
+		" added=" + s(i.AddedAt) + " removed=" + s(i.RemovedAt) + " completed=" + s(i.CompletedAt)
+}
+
+func TestBuildNotStarted(t *testing.T) {
+	if _, err := Build(jira.Sprint{ID: sprintID, Name: "Sprint 7"}, nil, nil, testOptions(day(2, 0))); err == nil {
+		t.Error("Build() error = nil, want error for a sprint that has not started")
+	}
+}
+
+func TestBuildCountsIssues(t *testing.T) {
+	opts := testOptions(day(10, 0))
+	opts.PointsField, opts.PointsName = "", ""
+	current := []jira.RawIssue{
+		newIssue("P-1", day(1, 9), statusDone, 5, statusChange(day(3, 12), statusToDo, statusDone)),
+		newIssue("P-2", day(1, 9), statusToDo, 8),
+	}
+	r, err := Build(closedSprint(), current, nil, opts)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if r.Unit != "issues" || r.Committed != (Totals{2, 2}) || r.Completed != (Totals{1, 1}) {
+		t.Errorf("Build() = unit %s, committed %+v, completed %+v; want issues, {2 2}, {1 1}", r.Unit, r.Committed, r.Completed)
+	}
+}
+
+func TestBurndown(t *testing.T) {
+	// One 4 point issue is done on the 3rd at 8:00 and a 2 point one is added on the 3rd at 12:00
+	issues := []jira.RawIssue{
+		newIssue("P-1", day(1, 9), statusDone, 4, statusChange(day(3, 8), statusToDo, statusDone)),
+		newIssue("P-2", day(1, 9), statusToDo, 2, sprintChange(day(3, 12), "", "7")),
+	}
+	active := closedSprint()
+	active.CompleteDate = nil
+
+	type point struct {
+		time      time.Time
+		remaining *float64
+		ideal     float64
+	}
+	tests := []struct {
+		name   string
+		sprint jira.Sprint
+		now    time.Time
+		want   []point
+	}{
+		{
+			name:   "closed after the planned end",
+			sprint: closedSprint(),
+			want: []point{
+				{day(2, 10), ptr(4.0), 4},
+				{day(3, 0), ptr(4.0), 4 * (1 - 14.0/48)},
+				{day(4, 0), ptr(2.0), 4 * (1 - 38.0/48)},
+				{day(4, 16), ptr(2.0), 0},
+			},
+		},
+		{
+			name:   "active sprint leaves future days empty",
+			sprint: active,
+			now:    day(3, 10),
+			want: []point{
+				{day(2, 10), ptr(4.0), 4},
+				{day(3, 0), ptr(4.0), 4 * (1 - 14.0/48)},
+				{day(4, 0), ptr(0.0), 4 * (1 - 38.0/48)},
+				{day(4, 10), nil, 0},
+			},
+		},
+		{
+			name: "closed early",
+			sprint: func() jira.Sprint {
+				s := closedSprint()
+				s.CompleteDate = ptr(day(3, 18))
+				return s
+			}(),
+			want: []point{
+				{day(2, 10), ptr(4.0), 4},
+				{day(3, 0), ptr(4.0), 4 * (1 - 14.0/48)},
+				{day(4, 0), ptr(2.0), 4 * (1 - 38.0/48)},
+				{day(4, 10), nil, 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Build(tt.sprint, issues, nil, testOptions(tt.now))
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if len(r.Burndown) != len(tt.want) {
+				t.Fatalf("burndown has %d points, want %d: %+v", len(r.Burndown), len(tt.want), r.Burndown)
+			}
+			for i, want := range tt.want {
+				got := r.Burndown[i]
+				if !got.Time.Equal(want.time) {
+					t.Errorf("point %d at %v, want %v", i, got.Time, want.time)
+				}
+				if (got.Remaining == nil) != (want.remaining == nil) || (got.Remaining != nil && *got.Remaining != *want.remaining) {
+					t.Errorf("point %d remaining = %v, want %v", i, deref(got.Remaining), deref(want.remaining))
+				}
+				if math.Abs(got.Ideal-want.ideal) > 1e-9 {
+					t.Errorf("point %d ideal = %v, want %v", i, got.Ideal, want.ideal)
+				}
+			}
+		})
+	}
+}
+
+func deref(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+func TestCheckFormat(t *testing.T) {
+	for _, format := range Formats {
+		if err := CheckFormat(format); err != nil {
+			t.Errorf("CheckFormat(%q) error = %v", format, err)
+		}
+	}
+	if err := CheckFormat("html"); err == nil {
+		t.Error(`CheckFormat("html") error = nil, want error`)
+	}
+}
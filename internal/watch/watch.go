@@ -0,0 +1,147 @@
+package watch
+
+import (
+	"sort"
+	"time"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+// Fields are the fields a snapshot needs from each issue
+var Fields = []string{"summary", "status", "assignee"}
+
+// Change types
+const (
+	Entered = "entered"
+	Left    = "left"
+	Changed = "changed"
+	// Updated is used by DiffResults for issues whose fields changed
+	Updated = "updated"
+)
+
+// IssueState is the part of an issue that is compared between polls
+type IssueState struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee"`
+}
+
+// Snapshot is the state of every issue matched by a query, keyed by issue key
+type Snapshot map[string]IssueState
+
+// Change is a difference between two snapshots. Previous is set for changed
+// issues; for issues that left the query Issue holds their last known state.
+type Change struct {
+	Type     string      `json:"type"`
+	Issue    IssueState  `json:"issue"`
+	Previous *IssueState `json:"previous,omitempty"`
+}
+
+// NewSnapshot builds a snapshot from search results fetched with Fields
+func NewSnapshot(issues []jira.RawIssue) Snapshot {
+	s := make(Snapshot, len(issues))
+	for _, issue := range issues {
+		s[issue.Key] = IssueState{
+			Key:      issue.Key,
+			Summary:  jira.FormatFieldValue(issue.Fields["summary"]),
+			Status:   jira.FormatFieldValue(issue.Fields["status"]),
+			Assignee: jira.FormatFieldValue(issue.Fields["assignee"]),
+		}
+	}
+	return s
+}
+
+// Diff returns the issues that entered, left or changed status or assignee between
+// prev and cur, ordered by issue key
+func Diff(prev, cur Snapshot) []Change {
+	var changes []Change
+	for key, issue := range cur {
+		old, ok := prev[key]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Type: Entered, Issue: issue})
+		case old.Status != issue.Status || old.Assignee != issue.Assignee:
+			previous := old
+			changes = append(changes, Change{Type: Changed, Issue: issue, Previous: &previous})
+		}
+	}
+	for key, issue := range prev {
+		if _, ok := cur[key]; !ok {
+			changes = append(changes, Change{Type: Left, Issue: issue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Issue.Key < changes[j].Issue.Key })
+	return changes
+}
+
+// ResultSet records the fields of every issue returned by a query run
+type ResultSet struct {
+	JQL   string    `json:"jql"`
+	RunAt time.Time `json:"run_at"`
+	// Issues maps issue keys to the display text of each fetched field, keyed by field ID
+	Issues map[string]map[string]string `json:"issues"`
+}
+
+// FieldChange is a field whose value differs between two runs
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// IssueDiff describes an issue that is new, removed or updated since the previous run.
+// Fields holds the current values, or the last known ones for removed issues.
+type IssueDiff struct {
+	Type    string
+	Key     string
+	Fields  map[string]string
+	Changes []FieldChange
+}
+
+// NewResultSet records the given fields of each issue, formatted for display
+func NewResultSet(jql string, issues []jira.RawIssue, fields []string) ResultSet {
+	rs := ResultSet{JQL: jql, RunAt: time.Now(), Issues: make(map[string]map[string]string, len(issues))}
+	for _, issue := range issues {
+		values := make(map[string]string, len(fields))
+		for _, f := range fields {
+			values[f] = jira.FormatFieldValue(issue.Fields[f])
+		}
+		rs.Issues[issue.Key] = values
+	}
+	return rs
+}
+
+// DiffResults returns the issues that are new in cur, missing from it, or whose fields
+// changed since prev, ordered by issue key. Fields fetched in only one of the runs are
+// not compared, so adding a column does not mark every issue as updated.
+func DiffResults(prev, cur ResultSet) []IssueDiff {
+	var diffs []IssueDiff
+	for key, values := range cur.Issues {
+		old, ok := prev.Issues[key]
+		if !ok {
+			diffs = append(diffs, IssueDiff{Type: Entered, Key: key, Fields: values})
+			continue
+		}
+
+		var changes []FieldChange
+		for field, value := range values {
+			if oldValue, ok := old[field]; ok && oldValue != value {
+				changes = append(changes, FieldChange{Field: field, Old: oldValue, New: value})
+			}
+		}
+		if len(changes) > 0 {
+			sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+			diffs = append(diffs, IssueDiff{Type: Updated, Key: key, Fields: values, Changes: changes})
+		}
+	}
+	for key, values := range prev.Issues {
+		if _, ok := cur.Issues[key]; !ok {
+			diffs = append(diffs, IssueDiff{Type: Left, Key: key, Fields: values})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Key < diffs[j].Key })
+	return diffs
+}
@@ -0,0 +1,187 @@
+package watch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eugenetaranov/jiractl/internal/jira"
+)
+
+func state(key, status, assignee string) IssueState {
+	return IssueState{Key: key, Summary: "Issue " + key, Status: status, Assignee: assignee}
+}
+
+func snapshot(states ...IssueState) Snapshot {
+	s := make(Snapshot, len(states))
+	for _, st := range states {
+		s[st.Key] = st
+	}
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		prev Snapshot
+		cur  Snapshot
+		want []Change
+	}{
+		{
+			name: "no changes",
+			prev: snapshot(state("P-1", "To Do", "")),
+			cur:  snapshot(state("P-1", "To Do", "")),
+		},
+		{
+			name: "entered",
+			prev: snapshot(),
+			cur:  snapshot(state("P-1", "To Do", "")),
+			want: []Change{{Type: Entered, Issue: state("P-1", "To Do", "")}},
+		},
+		{
+			name: "left keeps the last known state",
+			prev: snapshot(state("P-1", "In Progress", "Dana")),
+			cur:  snapshot(),
+			want: []Change{{Type: Left, Issue: state("P-1", "In Progress", "Dana")}},
+		},
+		{
+			name: "status changed",
+			prev: snapshot(state("P-1", "To Do", "Dana")),
+			cur:  snapshot(state("P-1", "Done", "Dana")),
+			want: []Change{{Type: Changed, Issue: state("P-1", "Done", "Dana"), Previous: ptr(state("P-1", "To Do", "Dana"))}},
+		},
+		{
+			name: "assignee changed",
+			prev: snapshot(state("P-1", "To Do", "")),
+			cur:  snapshot(state("P-1", "To Do", "Dana")),
+			want: []Change{{Type: Changed, Issue: state("P-1", "To Do", "Dana"), Previous: ptr(state("P-1", "To Do", ""))}},
+		},
+		{
+			name: "summary changes are ignored",
+			prev: snapshot(IssueState{Key: "P-1", Summary: "Old", Status: "To Do"}),
+			cur:  snapshot(IssueState{Key: "P-1", Summary: "New", Status: "To Do"}),
+		},
+		{
+			name: "ordered by key",
+			prev: snapshot(state("P-2", "To Do", ""), state("P-3", "To Do", "")),
+			cur:  snapshot(state("P-3", "Done", ""), state("P-1", "To Do", "")),
+			want: []Change{
+				{Type: Entered, Issue: state("P-1", "To Do", "")},
+				{Type: Left, Issue: state("P-2", "To Do", "")},
+				{Type: Changed, Issue: state("P-3", "Done", ""), Previous: ptr(state("P-3", "To Do", ""))},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.prev, tt.cur); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSnapshot(t *testing.T) {
+	issues := []jira.RawIssue{{
+		Key: "P-1",
+		Fields: map[string]interface{}{
+			"summary":  "Login loop",
+			"status":   map[string]interface{}{"name": "In Review"},
+			"assignee": map[string]interface{}{"displayName": "Dana", "name": "dana"},
+		},
+	}}
+	want := snapshot(IssueState{Key: "P-1", Summary: "Login loop", Status: "In Review", Assignee: "Dana"})
+	if got := NewSnapshot(issues); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSnapshot() = %+v, want %+v", got, want)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func results(issues map[string]map[string]string) ResultSet {
+	return ResultSet{JQL: "project = P", Issues: issues}
+}
+
+func TestDiffResults(t *testing.T) {
+	tests := []struct {
+		name string
+		prev map[string]map[string]string
+		cur  map[string]map[string]string
+		want []IssueDiff
+	}{
+		{
+			name: "no changes",
+			prev: map[string]map[string]string{"P-1": {"summary": "A", "status": "To Do"}},
+			cur:  map[string]map[string]string{"P-1": {"summary": "A", "status": "To Do"}},
+		},
+		{
+			name: "new issue",
+			prev: map[string]map[string]string{},
+			cur:  map[string]map[string]string{"P-1": {"summary": "A"}},
+			want: []IssueDiff{{Type: Entered, Key: "P-1", Fields: map[string]string{"summary": "A"}}},
+		},
+		{
+			name: "removed issue keeps its last values",
+			prev: map[string]map[string]string{"P-1": {"summary": "A"}},
+			cur:  map[string]map[string]string{},
+			want: []IssueDiff{{Type: Left, Key: "P-1", Fields: map[string]string{"summary": "A"}}},
+		},
+		{
+			name: "changed fields are sorted",
+			prev: map[string]map[string]string{"P-1": {"summary": "A", "status": "To Do", "assignee": ""}},
+			cur:  map[string]map[string]string{"P-1": {"summary": "A", "status": "Done", "assignee": "Dana"}},
+			want: []IssueDiff{{
+				Type:   Updated,
+				Key:    "P-1",
+				Fields: map[string]string{"summary": "A", "status": "Done", "assignee": "Dana"},
+				Changes: []FieldChange{
+					{Field: "assignee", Old: "", New: "Dana"},
+					{Field: "status", Old: "To Do", New: "Done"},
+				},
+			}},
+		},
+		{
+			name: "fields fetched in one run only are not compared",
+			prev: map[string]map[string]string{"P-1": {"summary": "A", "priority": "High"}},
+			cur:  map[string]map[string]string{"P-1": {"summary": "A", "labels": "ui"}},
+		},
+		{
+			name: "ordered by key",
+			prev: map[string]map[string]string{"P-2": {"summary": "B"}, "P-3": {"summary": "C"}},
+			cur:  map[string]map[string]string{"P-3": {"summary": "C2"}, "P-1": {"summary": "A"}},
+			want: []IssueDiff{
+				{Type: Entered, Key: "P-1", Fields: map[string]string{"summary": "A"}},
+				{Type: Left, Key: "P-2", Fields: map[string]string{"summary": "B"}},
+				{Type: Updated, Key: "P-3", Fields: map[string]string{"summary": "C2"}, Changes: []FieldChange{{Field: "summary", Old: "C", New: "C2"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffResults(results(tt.prev), results(tt.cur)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffResults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewResultSet(t *testing.T) {
+	issues := []jira.RawIssue{{
+		Key: "P-1",
+		Fields: map[string]interface{}{
+			"summary": "Login loop",
+			"status":  map[string]interface{}{"name": "In Review"},
+			"labels":  []interface{}{"ui", "auth"},
+		},
+	}}
+	got := NewResultSet("project = P", issues, []string{"summary", "status", "labels", "assignee"})
+	want := map[string]map[string]string{
+		"P-1": {"summary": "Login loop", "status": "In Review", "labels": "ui, auth", "assignee": ""},
+	}
+	if got.JQL != "project = P" || !reflect.DeepEqual(got.Issues, want) {
+		t.Errorf("NewResultSet() = %+v, want issues %+v", got, want)
+	}
+}